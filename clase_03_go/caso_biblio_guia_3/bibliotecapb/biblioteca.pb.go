@@ -0,0 +1,1187 @@
+// Servicio gRPC del núcleo de la biblioteca.
+//
+// Para regenerar el código Go:
+//   protoc --go_out=. --go_opt=paths=source_relative \
+//          --go-grpc_out=. --go-grpc_opt=paths=source_relative \
+//          bibliotecapb/biblioteca.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: bibliotecapb/biblioteca.proto
+
+package bibliotecapb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Libro struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Titulo        string                 `protobuf:"bytes,2,opt,name=titulo,proto3" json:"titulo,omitempty"`
+	Autor         string                 `protobuf:"bytes,3,opt,name=autor,proto3" json:"autor,omitempty"`
+	Isbn          string                 `protobuf:"bytes,4,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	Paginas       int32                  `protobuf:"varint,5,opt,name=paginas,proto3" json:"paginas,omitempty"`
+	Prestado      bool                   `protobuf:"varint,6,opt,name=prestado,proto3" json:"prestado,omitempty"`
+	Estado        string                 `protobuf:"bytes,7,opt,name=estado,proto3" json:"estado,omitempty"`
+	Ubicacion     string                 `protobuf:"bytes,8,opt,name=ubicacion,proto3" json:"ubicacion,omitempty"`
+	Categoria     string                 `protobuf:"bytes,9,opt,name=categoria,proto3" json:"categoria,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Libro) Reset() {
+	*x = Libro{}
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Libro) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Libro) ProtoMessage() {}
+
+func (x *Libro) ProtoReflect() protoreflect.Message {
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Libro.ProtoReflect.Descriptor instead.
+func (*Libro) Descriptor() ([]byte, []int) {
+	return file_bibliotecapb_biblioteca_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Libro) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Libro) GetTitulo() string {
+	if x != nil {
+		return x.Titulo
+	}
+	return ""
+}
+
+func (x *Libro) GetAutor() string {
+	if x != nil {
+		return x.Autor
+	}
+	return ""
+}
+
+func (x *Libro) GetIsbn() string {
+	if x != nil {
+		return x.Isbn
+	}
+	return ""
+}
+
+func (x *Libro) GetPaginas() int32 {
+	if x != nil {
+		return x.Paginas
+	}
+	return 0
+}
+
+func (x *Libro) GetPrestado() bool {
+	if x != nil {
+		return x.Prestado
+	}
+	return false
+}
+
+func (x *Libro) GetEstado() string {
+	if x != nil {
+		return x.Estado
+	}
+	return ""
+}
+
+func (x *Libro) GetUbicacion() string {
+	if x != nil {
+		return x.Ubicacion
+	}
+	return ""
+}
+
+func (x *Libro) GetCategoria() string {
+	if x != nil {
+		return x.Categoria
+	}
+	return ""
+}
+
+type Usuario struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Nombre        string                 `protobuf:"bytes,2,opt,name=nombre,proto3" json:"nombre,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Telefono      string                 `protobuf:"bytes,4,opt,name=telefono,proto3" json:"telefono,omitempty"`
+	Activo        bool                   `protobuf:"varint,5,opt,name=activo,proto3" json:"activo,omitempty"`
+	Categoria     string                 `protobuf:"bytes,6,opt,name=categoria,proto3" json:"categoria,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Usuario) Reset() {
+	*x = Usuario{}
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Usuario) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Usuario) ProtoMessage() {}
+
+func (x *Usuario) ProtoReflect() protoreflect.Message {
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Usuario.ProtoReflect.Descriptor instead.
+func (*Usuario) Descriptor() ([]byte, []int) {
+	return file_bibliotecapb_biblioteca_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Usuario) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Usuario) GetNombre() string {
+	if x != nil {
+		return x.Nombre
+	}
+	return ""
+}
+
+func (x *Usuario) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Usuario) GetTelefono() string {
+	if x != nil {
+		return x.Telefono
+	}
+	return ""
+}
+
+func (x *Usuario) GetActivo() bool {
+	if x != nil {
+		return x.Activo
+	}
+	return false
+}
+
+func (x *Usuario) GetCategoria() string {
+	if x != nil {
+		return x.Categoria
+	}
+	return ""
+}
+
+type Prestamo struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	LibroId         int64                  `protobuf:"varint,2,opt,name=libro_id,json=libroId,proto3" json:"libro_id,omitempty"`
+	UsuarioId       int64                  `protobuf:"varint,3,opt,name=usuario_id,json=usuarioId,proto3" json:"usuario_id,omitempty"`
+	FechaPrestamo   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=fecha_prestamo,json=fechaPrestamo,proto3" json:"fecha_prestamo,omitempty"`
+	FechaDevolucion *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=fecha_devolucion,json=fechaDevolucion,proto3" json:"fecha_devolucion,omitempty"`
+	Devuelto        bool                   `protobuf:"varint,6,opt,name=devuelto,proto3" json:"devuelto,omitempty"`
+	Renovaciones    int32                  `protobuf:"varint,7,opt,name=renovaciones,proto3" json:"renovaciones,omitempty"`
+	MaxRenovaciones int32                  `protobuf:"varint,8,opt,name=max_renovaciones,json=maxRenovaciones,proto3" json:"max_renovaciones,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Prestamo) Reset() {
+	*x = Prestamo{}
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Prestamo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Prestamo) ProtoMessage() {}
+
+func (x *Prestamo) ProtoReflect() protoreflect.Message {
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Prestamo.ProtoReflect.Descriptor instead.
+func (*Prestamo) Descriptor() ([]byte, []int) {
+	return file_bibliotecapb_biblioteca_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Prestamo) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Prestamo) GetLibroId() int64 {
+	if x != nil {
+		return x.LibroId
+	}
+	return 0
+}
+
+func (x *Prestamo) GetUsuarioId() int64 {
+	if x != nil {
+		return x.UsuarioId
+	}
+	return 0
+}
+
+func (x *Prestamo) GetFechaPrestamo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FechaPrestamo
+	}
+	return nil
+}
+
+func (x *Prestamo) GetFechaDevolucion() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FechaDevolucion
+	}
+	return nil
+}
+
+func (x *Prestamo) GetDevuelto() bool {
+	if x != nil {
+		return x.Devuelto
+	}
+	return false
+}
+
+func (x *Prestamo) GetRenovaciones() int32 {
+	if x != nil {
+		return x.Renovaciones
+	}
+	return 0
+}
+
+func (x *Prestamo) GetMaxRenovaciones() int32 {
+	if x != nil {
+		return x.MaxRenovaciones
+	}
+	return 0
+}
+
+type Evento struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Tipo          string                 `protobuf:"bytes,2,opt,name=tipo,proto3" json:"tipo,omitempty"`
+	Fecha         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=fecha,proto3" json:"fecha,omitempty"`
+	LibroId       int64                  `protobuf:"varint,4,opt,name=libro_id,json=libroId,proto3" json:"libro_id,omitempty"`
+	UsuarioId     int64                  `protobuf:"varint,5,opt,name=usuario_id,json=usuarioId,proto3" json:"usuario_id,omitempty"`
+	PrestamoId    int64                  `protobuf:"varint,6,opt,name=prestamo_id,json=prestamoId,proto3" json:"prestamo_id,omitempty"`
+	MultaId       int64                  `protobuf:"varint,7,opt,name=multa_id,json=multaId,proto3" json:"multa_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Evento) Reset() {
+	*x = Evento{}
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Evento) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Evento) ProtoMessage() {}
+
+func (x *Evento) ProtoReflect() protoreflect.Message {
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Evento.ProtoReflect.Descriptor instead.
+func (*Evento) Descriptor() ([]byte, []int) {
+	return file_bibliotecapb_biblioteca_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Evento) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Evento) GetTipo() string {
+	if x != nil {
+		return x.Tipo
+	}
+	return ""
+}
+
+func (x *Evento) GetFecha() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Fecha
+	}
+	return nil
+}
+
+func (x *Evento) GetLibroId() int64 {
+	if x != nil {
+		return x.LibroId
+	}
+	return 0
+}
+
+func (x *Evento) GetUsuarioId() int64 {
+	if x != nil {
+		return x.UsuarioId
+	}
+	return 0
+}
+
+func (x *Evento) GetPrestamoId() int64 {
+	if x != nil {
+		return x.PrestamoId
+	}
+	return 0
+}
+
+func (x *Evento) GetMultaId() int64 {
+	if x != nil {
+		return x.MultaId
+	}
+	return 0
+}
+
+type AgregarLibroRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Titulo        string                 `protobuf:"bytes,1,opt,name=titulo,proto3" json:"titulo,omitempty"`
+	Autor         string                 `protobuf:"bytes,2,opt,name=autor,proto3" json:"autor,omitempty"`
+	Isbn          string                 `protobuf:"bytes,3,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	Paginas       int32                  `protobuf:"varint,4,opt,name=paginas,proto3" json:"paginas,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgregarLibroRequest) Reset() {
+	*x = AgregarLibroRequest{}
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgregarLibroRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgregarLibroRequest) ProtoMessage() {}
+
+func (x *AgregarLibroRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgregarLibroRequest.ProtoReflect.Descriptor instead.
+func (*AgregarLibroRequest) Descriptor() ([]byte, []int) {
+	return file_bibliotecapb_biblioteca_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AgregarLibroRequest) GetTitulo() string {
+	if x != nil {
+		return x.Titulo
+	}
+	return ""
+}
+
+func (x *AgregarLibroRequest) GetAutor() string {
+	if x != nil {
+		return x.Autor
+	}
+	return ""
+}
+
+func (x *AgregarLibroRequest) GetIsbn() string {
+	if x != nil {
+		return x.Isbn
+	}
+	return ""
+}
+
+func (x *AgregarLibroRequest) GetPaginas() int32 {
+	if x != nil {
+		return x.Paginas
+	}
+	return 0
+}
+
+type ObtenerLibroRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObtenerLibroRequest) Reset() {
+	*x = ObtenerLibroRequest{}
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObtenerLibroRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObtenerLibroRequest) ProtoMessage() {}
+
+func (x *ObtenerLibroRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObtenerLibroRequest.ProtoReflect.Descriptor instead.
+func (*ObtenerLibroRequest) Descriptor() ([]byte, []int) {
+	return file_bibliotecapb_biblioteca_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ObtenerLibroRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListarLibrosRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Texto a buscar en título o autor; vacío lista todos
+	Consulta        string `protobuf:"bytes,1,opt,name=consulta,proto3" json:"consulta,omitempty"`
+	SoloDisponibles bool   `protobuf:"varint,2,opt,name=solo_disponibles,json=soloDisponibles,proto3" json:"solo_disponibles,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListarLibrosRequest) Reset() {
+	*x = ListarLibrosRequest{}
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListarLibrosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListarLibrosRequest) ProtoMessage() {}
+
+func (x *ListarLibrosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListarLibrosRequest.ProtoReflect.Descriptor instead.
+func (*ListarLibrosRequest) Descriptor() ([]byte, []int) {
+	return file_bibliotecapb_biblioteca_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListarLibrosRequest) GetConsulta() string {
+	if x != nil {
+		return x.Consulta
+	}
+	return ""
+}
+
+func (x *ListarLibrosRequest) GetSoloDisponibles() bool {
+	if x != nil {
+		return x.SoloDisponibles
+	}
+	return false
+}
+
+type ListarLibrosResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Libros        []*Libro               `protobuf:"bytes,1,rep,name=libros,proto3" json:"libros,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListarLibrosResponse) Reset() {
+	*x = ListarLibrosResponse{}
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListarLibrosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListarLibrosResponse) ProtoMessage() {}
+
+func (x *ListarLibrosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListarLibrosResponse.ProtoReflect.Descriptor instead.
+func (*ListarLibrosResponse) Descriptor() ([]byte, []int) {
+	return file_bibliotecapb_biblioteca_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListarLibrosResponse) GetLibros() []*Libro {
+	if x != nil {
+		return x.Libros
+	}
+	return nil
+}
+
+type RegistrarUsuarioRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nombre        string                 `protobuf:"bytes,1,opt,name=nombre,proto3" json:"nombre,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Telefono      string                 `protobuf:"bytes,3,opt,name=telefono,proto3" json:"telefono,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegistrarUsuarioRequest) Reset() {
+	*x = RegistrarUsuarioRequest{}
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegistrarUsuarioRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistrarUsuarioRequest) ProtoMessage() {}
+
+func (x *RegistrarUsuarioRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistrarUsuarioRequest.ProtoReflect.Descriptor instead.
+func (*RegistrarUsuarioRequest) Descriptor() ([]byte, []int) {
+	return file_bibliotecapb_biblioteca_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RegistrarUsuarioRequest) GetNombre() string {
+	if x != nil {
+		return x.Nombre
+	}
+	return ""
+}
+
+func (x *RegistrarUsuarioRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *RegistrarUsuarioRequest) GetTelefono() string {
+	if x != nil {
+		return x.Telefono
+	}
+	return ""
+}
+
+type ObtenerUsuarioRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObtenerUsuarioRequest) Reset() {
+	*x = ObtenerUsuarioRequest{}
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObtenerUsuarioRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObtenerUsuarioRequest) ProtoMessage() {}
+
+func (x *ObtenerUsuarioRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObtenerUsuarioRequest.ProtoReflect.Descriptor instead.
+func (*ObtenerUsuarioRequest) Descriptor() ([]byte, []int) {
+	return file_bibliotecapb_biblioteca_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ObtenerUsuarioRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type PrestarLibroRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LibroId       int64                  `protobuf:"varint,1,opt,name=libro_id,json=libroId,proto3" json:"libro_id,omitempty"`
+	UsuarioId     int64                  `protobuf:"varint,2,opt,name=usuario_id,json=usuarioId,proto3" json:"usuario_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PrestarLibroRequest) Reset() {
+	*x = PrestarLibroRequest{}
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PrestarLibroRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrestarLibroRequest) ProtoMessage() {}
+
+func (x *PrestarLibroRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrestarLibroRequest.ProtoReflect.Descriptor instead.
+func (*PrestarLibroRequest) Descriptor() ([]byte, []int) {
+	return file_bibliotecapb_biblioteca_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PrestarLibroRequest) GetLibroId() int64 {
+	if x != nil {
+		return x.LibroId
+	}
+	return 0
+}
+
+func (x *PrestarLibroRequest) GetUsuarioId() int64 {
+	if x != nil {
+		return x.UsuarioId
+	}
+	return 0
+}
+
+type DevolverLibroRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LibroId       int64                  `protobuf:"varint,1,opt,name=libro_id,json=libroId,proto3" json:"libro_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DevolverLibroRequest) Reset() {
+	*x = DevolverLibroRequest{}
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DevolverLibroRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DevolverLibroRequest) ProtoMessage() {}
+
+func (x *DevolverLibroRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DevolverLibroRequest.ProtoReflect.Descriptor instead.
+func (*DevolverLibroRequest) Descriptor() ([]byte, []int) {
+	return file_bibliotecapb_biblioteca_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DevolverLibroRequest) GetLibroId() int64 {
+	if x != nil {
+		return x.LibroId
+	}
+	return 0
+}
+
+type RenovarPrestamoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LibroId       int64                  `protobuf:"varint,1,opt,name=libro_id,json=libroId,proto3" json:"libro_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenovarPrestamoRequest) Reset() {
+	*x = RenovarPrestamoRequest{}
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenovarPrestamoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenovarPrestamoRequest) ProtoMessage() {}
+
+func (x *RenovarPrestamoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenovarPrestamoRequest.ProtoReflect.Descriptor instead.
+func (*RenovarPrestamoRequest) Descriptor() ([]byte, []int) {
+	return file_bibliotecapb_biblioteca_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RenovarPrestamoRequest) GetLibroId() int64 {
+	if x != nil {
+		return x.LibroId
+	}
+	return 0
+}
+
+type ListarPrestamosRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 0 lista los préstamos de todos los usuarios
+	UsuarioId     int64 `protobuf:"varint,1,opt,name=usuario_id,json=usuarioId,proto3" json:"usuario_id,omitempty"`
+	SoloActivos   bool  `protobuf:"varint,2,opt,name=solo_activos,json=soloActivos,proto3" json:"solo_activos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListarPrestamosRequest) Reset() {
+	*x = ListarPrestamosRequest{}
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListarPrestamosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListarPrestamosRequest) ProtoMessage() {}
+
+func (x *ListarPrestamosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListarPrestamosRequest.ProtoReflect.Descriptor instead.
+func (*ListarPrestamosRequest) Descriptor() ([]byte, []int) {
+	return file_bibliotecapb_biblioteca_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListarPrestamosRequest) GetUsuarioId() int64 {
+	if x != nil {
+		return x.UsuarioId
+	}
+	return 0
+}
+
+func (x *ListarPrestamosRequest) GetSoloActivos() bool {
+	if x != nil {
+		return x.SoloActivos
+	}
+	return false
+}
+
+type ListarPrestamosResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prestamos     []*Prestamo            `protobuf:"bytes,1,rep,name=prestamos,proto3" json:"prestamos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListarPrestamosResponse) Reset() {
+	*x = ListarPrestamosResponse{}
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListarPrestamosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListarPrestamosResponse) ProtoMessage() {}
+
+func (x *ListarPrestamosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListarPrestamosResponse.ProtoReflect.Descriptor instead.
+func (*ListarPrestamosResponse) Descriptor() ([]byte, []int) {
+	return file_bibliotecapb_biblioteca_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListarPrestamosResponse) GetPrestamos() []*Prestamo {
+	if x != nil {
+		return x.Prestamos
+	}
+	return nil
+}
+
+type ObservarEventosRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Tipos de evento a recibir ("libro.prestado", ...); vacío recibe todos
+	Tipos         []string `protobuf:"bytes,1,rep,name=tipos,proto3" json:"tipos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObservarEventosRequest) Reset() {
+	*x = ObservarEventosRequest{}
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObservarEventosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObservarEventosRequest) ProtoMessage() {}
+
+func (x *ObservarEventosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bibliotecapb_biblioteca_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObservarEventosRequest.ProtoReflect.Descriptor instead.
+func (*ObservarEventosRequest) Descriptor() ([]byte, []int) {
+	return file_bibliotecapb_biblioteca_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ObservarEventosRequest) GetTipos() []string {
+	if x != nil {
+		return x.Tipos
+	}
+	return nil
+}
+
+var File_bibliotecapb_biblioteca_proto protoreflect.FileDescriptor
+
+const file_bibliotecapb_biblioteca_proto_rawDesc = "" +
+	"\n" +
+	"\x1dbibliotecapb/biblioteca.proto\x12\rbiblioteca.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe3\x01\n" +
+	"\x05Libro\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06titulo\x18\x02 \x01(\tR\x06titulo\x12\x14\n" +
+	"\x05autor\x18\x03 \x01(\tR\x05autor\x12\x12\n" +
+	"\x04isbn\x18\x04 \x01(\tR\x04isbn\x12\x18\n" +
+	"\apaginas\x18\x05 \x01(\x05R\apaginas\x12\x1a\n" +
+	"\bprestado\x18\x06 \x01(\bR\bprestado\x12\x16\n" +
+	"\x06estado\x18\a \x01(\tR\x06estado\x12\x1c\n" +
+	"\tubicacion\x18\b \x01(\tR\tubicacion\x12\x1c\n" +
+	"\tcategoria\x18\t \x01(\tR\tcategoria\"\x99\x01\n" +
+	"\aUsuario\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06nombre\x18\x02 \x01(\tR\x06nombre\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x1a\n" +
+	"\btelefono\x18\x04 \x01(\tR\btelefono\x12\x16\n" +
+	"\x06activo\x18\x05 \x01(\bR\x06activo\x12\x1c\n" +
+	"\tcategoria\x18\x06 \x01(\tR\tcategoria\"\xc9\x02\n" +
+	"\bPrestamo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\blibro_id\x18\x02 \x01(\x03R\alibroId\x12\x1d\n" +
+	"\n" +
+	"usuario_id\x18\x03 \x01(\x03R\tusuarioId\x12A\n" +
+	"\x0efecha_prestamo\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rfechaPrestamo\x12E\n" +
+	"\x10fecha_devolucion\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x0ffechaDevolucion\x12\x1a\n" +
+	"\bdevuelto\x18\x06 \x01(\bR\bdevuelto\x12\"\n" +
+	"\frenovaciones\x18\a \x01(\x05R\frenovaciones\x12)\n" +
+	"\x10max_renovaciones\x18\b \x01(\x05R\x0fmaxRenovaciones\"\xd4\x01\n" +
+	"\x06Evento\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04tipo\x18\x02 \x01(\tR\x04tipo\x120\n" +
+	"\x05fecha\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05fecha\x12\x19\n" +
+	"\blibro_id\x18\x04 \x01(\x03R\alibroId\x12\x1d\n" +
+	"\n" +
+	"usuario_id\x18\x05 \x01(\x03R\tusuarioId\x12\x1f\n" +
+	"\vprestamo_id\x18\x06 \x01(\x03R\n" +
+	"prestamoId\x12\x19\n" +
+	"\bmulta_id\x18\a \x01(\x03R\amultaId\"q\n" +
+	"\x13AgregarLibroRequest\x12\x16\n" +
+	"\x06titulo\x18\x01 \x01(\tR\x06titulo\x12\x14\n" +
+	"\x05autor\x18\x02 \x01(\tR\x05autor\x12\x12\n" +
+	"\x04isbn\x18\x03 \x01(\tR\x04isbn\x12\x18\n" +
+	"\apaginas\x18\x04 \x01(\x05R\apaginas\"%\n" +
+	"\x13ObtenerLibroRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\\\n" +
+	"\x13ListarLibrosRequest\x12\x1a\n" +
+	"\bconsulta\x18\x01 \x01(\tR\bconsulta\x12)\n" +
+	"\x10solo_disponibles\x18\x02 \x01(\bR\x0fsoloDisponibles\"D\n" +
+	"\x14ListarLibrosResponse\x12,\n" +
+	"\x06libros\x18\x01 \x03(\v2\x14.biblioteca.v1.LibroR\x06libros\"c\n" +
+	"\x17RegistrarUsuarioRequest\x12\x16\n" +
+	"\x06nombre\x18\x01 \x01(\tR\x06nombre\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1a\n" +
+	"\btelefono\x18\x03 \x01(\tR\btelefono\"'\n" +
+	"\x15ObtenerUsuarioRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"O\n" +
+	"\x13PrestarLibroRequest\x12\x19\n" +
+	"\blibro_id\x18\x01 \x01(\x03R\alibroId\x12\x1d\n" +
+	"\n" +
+	"usuario_id\x18\x02 \x01(\x03R\tusuarioId\"1\n" +
+	"\x14DevolverLibroRequest\x12\x19\n" +
+	"\blibro_id\x18\x01 \x01(\x03R\alibroId\"3\n" +
+	"\x16RenovarPrestamoRequest\x12\x19\n" +
+	"\blibro_id\x18\x01 \x01(\x03R\alibroId\"Z\n" +
+	"\x16ListarPrestamosRequest\x12\x1d\n" +
+	"\n" +
+	"usuario_id\x18\x01 \x01(\x03R\tusuarioId\x12!\n" +
+	"\fsolo_activos\x18\x02 \x01(\bR\vsoloActivos\"P\n" +
+	"\x17ListarPrestamosResponse\x125\n" +
+	"\tprestamos\x18\x01 \x03(\v2\x17.biblioteca.v1.PrestamoR\tprestamos\".\n" +
+	"\x16ObservarEventosRequest\x12\x14\n" +
+	"\x05tipos\x18\x01 \x03(\tR\x05tipos2\xc1\x06\n" +
+	"\n" +
+	"Biblioteca\x12H\n" +
+	"\fAgregarLibro\x12\".biblioteca.v1.AgregarLibroRequest\x1a\x14.biblioteca.v1.Libro\x12H\n" +
+	"\fObtenerLibro\x12\".biblioteca.v1.ObtenerLibroRequest\x1a\x14.biblioteca.v1.Libro\x12W\n" +
+	"\fListarLibros\x12\".biblioteca.v1.ListarLibrosRequest\x1a#.biblioteca.v1.ListarLibrosResponse\x12R\n" +
+	"\x10RegistrarUsuario\x12&.biblioteca.v1.RegistrarUsuarioRequest\x1a\x16.biblioteca.v1.Usuario\x12N\n" +
+	"\x0eObtenerUsuario\x12$.biblioteca.v1.ObtenerUsuarioRequest\x1a\x16.biblioteca.v1.Usuario\x12K\n" +
+	"\fPrestarLibro\x12\".biblioteca.v1.PrestarLibroRequest\x1a\x17.biblioteca.v1.Prestamo\x12M\n" +
+	"\rDevolverLibro\x12#.biblioteca.v1.DevolverLibroRequest\x1a\x17.biblioteca.v1.Prestamo\x12Q\n" +
+	"\x0fRenovarPrestamo\x12%.biblioteca.v1.RenovarPrestamoRequest\x1a\x17.biblioteca.v1.Prestamo\x12`\n" +
+	"\x0fListarPrestamos\x12%.biblioteca.v1.ListarPrestamosRequest\x1a&.biblioteca.v1.ListarPrestamosResponse\x12Q\n" +
+	"\x0fObservarEventos\x12%.biblioteca.v1.ObservarEventosRequest\x1a\x15.biblioteca.v1.Evento0\x01B\x15Z\x13biblio/bibliotecapbb\x06proto3"
+
+var (
+	file_bibliotecapb_biblioteca_proto_rawDescOnce sync.Once
+	file_bibliotecapb_biblioteca_proto_rawDescData []byte
+)
+
+func file_bibliotecapb_biblioteca_proto_rawDescGZIP() []byte {
+	file_bibliotecapb_biblioteca_proto_rawDescOnce.Do(func() {
+		file_bibliotecapb_biblioteca_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_bibliotecapb_biblioteca_proto_rawDesc), len(file_bibliotecapb_biblioteca_proto_rawDesc)))
+	})
+	return file_bibliotecapb_biblioteca_proto_rawDescData
+}
+
+var file_bibliotecapb_biblioteca_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_bibliotecapb_biblioteca_proto_goTypes = []any{
+	(*Libro)(nil),                   // 0: biblioteca.v1.Libro
+	(*Usuario)(nil),                 // 1: biblioteca.v1.Usuario
+	(*Prestamo)(nil),                // 2: biblioteca.v1.Prestamo
+	(*Evento)(nil),                  // 3: biblioteca.v1.Evento
+	(*AgregarLibroRequest)(nil),     // 4: biblioteca.v1.AgregarLibroRequest
+	(*ObtenerLibroRequest)(nil),     // 5: biblioteca.v1.ObtenerLibroRequest
+	(*ListarLibrosRequest)(nil),     // 6: biblioteca.v1.ListarLibrosRequest
+	(*ListarLibrosResponse)(nil),    // 7: biblioteca.v1.ListarLibrosResponse
+	(*RegistrarUsuarioRequest)(nil), // 8: biblioteca.v1.RegistrarUsuarioRequest
+	(*ObtenerUsuarioRequest)(nil),   // 9: biblioteca.v1.ObtenerUsuarioRequest
+	(*PrestarLibroRequest)(nil),     // 10: biblioteca.v1.PrestarLibroRequest
+	(*DevolverLibroRequest)(nil),    // 11: biblioteca.v1.DevolverLibroRequest
+	(*RenovarPrestamoRequest)(nil),  // 12: biblioteca.v1.RenovarPrestamoRequest
+	(*ListarPrestamosRequest)(nil),  // 13: biblioteca.v1.ListarPrestamosRequest
+	(*ListarPrestamosResponse)(nil), // 14: biblioteca.v1.ListarPrestamosResponse
+	(*ObservarEventosRequest)(nil),  // 15: biblioteca.v1.ObservarEventosRequest
+	(*timestamppb.Timestamp)(nil),   // 16: google.protobuf.Timestamp
+}
+var file_bibliotecapb_biblioteca_proto_depIdxs = []int32{
+	16, // 0: biblioteca.v1.Prestamo.fecha_prestamo:type_name -> google.protobuf.Timestamp
+	16, // 1: biblioteca.v1.Prestamo.fecha_devolucion:type_name -> google.protobuf.Timestamp
+	16, // 2: biblioteca.v1.Evento.fecha:type_name -> google.protobuf.Timestamp
+	0,  // 3: biblioteca.v1.ListarLibrosResponse.libros:type_name -> biblioteca.v1.Libro
+	2,  // 4: biblioteca.v1.ListarPrestamosResponse.prestamos:type_name -> biblioteca.v1.Prestamo
+	4,  // 5: biblioteca.v1.Biblioteca.AgregarLibro:input_type -> biblioteca.v1.AgregarLibroRequest
+	5,  // 6: biblioteca.v1.Biblioteca.ObtenerLibro:input_type -> biblioteca.v1.ObtenerLibroRequest
+	6,  // 7: biblioteca.v1.Biblioteca.ListarLibros:input_type -> biblioteca.v1.ListarLibrosRequest
+	8,  // 8: biblioteca.v1.Biblioteca.RegistrarUsuario:input_type -> biblioteca.v1.RegistrarUsuarioRequest
+	9,  // 9: biblioteca.v1.Biblioteca.ObtenerUsuario:input_type -> biblioteca.v1.ObtenerUsuarioRequest
+	10, // 10: biblioteca.v1.Biblioteca.PrestarLibro:input_type -> biblioteca.v1.PrestarLibroRequest
+	11, // 11: biblioteca.v1.Biblioteca.DevolverLibro:input_type -> biblioteca.v1.DevolverLibroRequest
+	12, // 12: biblioteca.v1.Biblioteca.RenovarPrestamo:input_type -> biblioteca.v1.RenovarPrestamoRequest
+	13, // 13: biblioteca.v1.Biblioteca.ListarPrestamos:input_type -> biblioteca.v1.ListarPrestamosRequest
+	15, // 14: biblioteca.v1.Biblioteca.ObservarEventos:input_type -> biblioteca.v1.ObservarEventosRequest
+	0,  // 15: biblioteca.v1.Biblioteca.AgregarLibro:output_type -> biblioteca.v1.Libro
+	0,  // 16: biblioteca.v1.Biblioteca.ObtenerLibro:output_type -> biblioteca.v1.Libro
+	7,  // 17: biblioteca.v1.Biblioteca.ListarLibros:output_type -> biblioteca.v1.ListarLibrosResponse
+	1,  // 18: biblioteca.v1.Biblioteca.RegistrarUsuario:output_type -> biblioteca.v1.Usuario
+	1,  // 19: biblioteca.v1.Biblioteca.ObtenerUsuario:output_type -> biblioteca.v1.Usuario
+	2,  // 20: biblioteca.v1.Biblioteca.PrestarLibro:output_type -> biblioteca.v1.Prestamo
+	2,  // 21: biblioteca.v1.Biblioteca.DevolverLibro:output_type -> biblioteca.v1.Prestamo
+	2,  // 22: biblioteca.v1.Biblioteca.RenovarPrestamo:output_type -> biblioteca.v1.Prestamo
+	14, // 23: biblioteca.v1.Biblioteca.ListarPrestamos:output_type -> biblioteca.v1.ListarPrestamosResponse
+	3,  // 24: biblioteca.v1.Biblioteca.ObservarEventos:output_type -> biblioteca.v1.Evento
+	15, // [15:25] is the sub-list for method output_type
+	5,  // [5:15] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_bibliotecapb_biblioteca_proto_init() }
+func file_bibliotecapb_biblioteca_proto_init() {
+	if File_bibliotecapb_biblioteca_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bibliotecapb_biblioteca_proto_rawDesc), len(file_bibliotecapb_biblioteca_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bibliotecapb_biblioteca_proto_goTypes,
+		DependencyIndexes: file_bibliotecapb_biblioteca_proto_depIdxs,
+		MessageInfos:      file_bibliotecapb_biblioteca_proto_msgTypes,
+	}.Build()
+	File_bibliotecapb_biblioteca_proto = out.File
+	file_bibliotecapb_biblioteca_proto_goTypes = nil
+	file_bibliotecapb_biblioteca_proto_depIdxs = nil
+}
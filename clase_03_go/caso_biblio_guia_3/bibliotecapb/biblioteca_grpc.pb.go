@@ -0,0 +1,482 @@
+// Servicio gRPC del núcleo de la biblioteca.
+//
+// Para regenerar el código Go:
+//   protoc --go_out=. --go_opt=paths=source_relative \
+//          --go-grpc_out=. --go-grpc_opt=paths=source_relative \
+//          bibliotecapb/biblioteca.proto
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: bibliotecapb/biblioteca.proto
+
+package bibliotecapb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Biblioteca_AgregarLibro_FullMethodName     = "/biblioteca.v1.Biblioteca/AgregarLibro"
+	Biblioteca_ObtenerLibro_FullMethodName     = "/biblioteca.v1.Biblioteca/ObtenerLibro"
+	Biblioteca_ListarLibros_FullMethodName     = "/biblioteca.v1.Biblioteca/ListarLibros"
+	Biblioteca_RegistrarUsuario_FullMethodName = "/biblioteca.v1.Biblioteca/RegistrarUsuario"
+	Biblioteca_ObtenerUsuario_FullMethodName   = "/biblioteca.v1.Biblioteca/ObtenerUsuario"
+	Biblioteca_PrestarLibro_FullMethodName     = "/biblioteca.v1.Biblioteca/PrestarLibro"
+	Biblioteca_DevolverLibro_FullMethodName    = "/biblioteca.v1.Biblioteca/DevolverLibro"
+	Biblioteca_RenovarPrestamo_FullMethodName  = "/biblioteca.v1.Biblioteca/RenovarPrestamo"
+	Biblioteca_ListarPrestamos_FullMethodName  = "/biblioteca.v1.Biblioteca/ListarPrestamos"
+	Biblioteca_ObservarEventos_FullMethodName  = "/biblioteca.v1.Biblioteca/ObservarEventos"
+)
+
+// BibliotecaClient is the client API for Biblioteca service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BibliotecaClient interface {
+	// Libros
+	AgregarLibro(ctx context.Context, in *AgregarLibroRequest, opts ...grpc.CallOption) (*Libro, error)
+	ObtenerLibro(ctx context.Context, in *ObtenerLibroRequest, opts ...grpc.CallOption) (*Libro, error)
+	ListarLibros(ctx context.Context, in *ListarLibrosRequest, opts ...grpc.CallOption) (*ListarLibrosResponse, error)
+	// Usuarios
+	RegistrarUsuario(ctx context.Context, in *RegistrarUsuarioRequest, opts ...grpc.CallOption) (*Usuario, error)
+	ObtenerUsuario(ctx context.Context, in *ObtenerUsuarioRequest, opts ...grpc.CallOption) (*Usuario, error)
+	// Préstamos
+	PrestarLibro(ctx context.Context, in *PrestarLibroRequest, opts ...grpc.CallOption) (*Prestamo, error)
+	DevolverLibro(ctx context.Context, in *DevolverLibroRequest, opts ...grpc.CallOption) (*Prestamo, error)
+	RenovarPrestamo(ctx context.Context, in *RenovarPrestamoRequest, opts ...grpc.CallOption) (*Prestamo, error)
+	ListarPrestamos(ctx context.Context, in *ListarPrestamosRequest, opts ...grpc.CallOption) (*ListarPrestamosResponse, error)
+	// ObservarEventos emite los eventos de la biblioteca a medida que ocurren
+	ObservarEventos(ctx context.Context, in *ObservarEventosRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Evento], error)
+}
+
+type bibliotecaClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBibliotecaClient(cc grpc.ClientConnInterface) BibliotecaClient {
+	return &bibliotecaClient{cc}
+}
+
+func (c *bibliotecaClient) AgregarLibro(ctx context.Context, in *AgregarLibroRequest, opts ...grpc.CallOption) (*Libro, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Libro)
+	err := c.cc.Invoke(ctx, Biblioteca_AgregarLibro_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bibliotecaClient) ObtenerLibro(ctx context.Context, in *ObtenerLibroRequest, opts ...grpc.CallOption) (*Libro, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Libro)
+	err := c.cc.Invoke(ctx, Biblioteca_ObtenerLibro_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bibliotecaClient) ListarLibros(ctx context.Context, in *ListarLibrosRequest, opts ...grpc.CallOption) (*ListarLibrosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListarLibrosResponse)
+	err := c.cc.Invoke(ctx, Biblioteca_ListarLibros_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bibliotecaClient) RegistrarUsuario(ctx context.Context, in *RegistrarUsuarioRequest, opts ...grpc.CallOption) (*Usuario, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Usuario)
+	err := c.cc.Invoke(ctx, Biblioteca_RegistrarUsuario_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bibliotecaClient) ObtenerUsuario(ctx context.Context, in *ObtenerUsuarioRequest, opts ...grpc.CallOption) (*Usuario, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Usuario)
+	err := c.cc.Invoke(ctx, Biblioteca_ObtenerUsuario_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bibliotecaClient) PrestarLibro(ctx context.Context, in *PrestarLibroRequest, opts ...grpc.CallOption) (*Prestamo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Prestamo)
+	err := c.cc.Invoke(ctx, Biblioteca_PrestarLibro_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bibliotecaClient) DevolverLibro(ctx context.Context, in *DevolverLibroRequest, opts ...grpc.CallOption) (*Prestamo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Prestamo)
+	err := c.cc.Invoke(ctx, Biblioteca_DevolverLibro_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bibliotecaClient) RenovarPrestamo(ctx context.Context, in *RenovarPrestamoRequest, opts ...grpc.CallOption) (*Prestamo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Prestamo)
+	err := c.cc.Invoke(ctx, Biblioteca_RenovarPrestamo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bibliotecaClient) ListarPrestamos(ctx context.Context, in *ListarPrestamosRequest, opts ...grpc.CallOption) (*ListarPrestamosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListarPrestamosResponse)
+	err := c.cc.Invoke(ctx, Biblioteca_ListarPrestamos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bibliotecaClient) ObservarEventos(ctx context.Context, in *ObservarEventosRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Evento], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Biblioteca_ServiceDesc.Streams[0], Biblioteca_ObservarEventos_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ObservarEventosRequest, Evento]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Biblioteca_ObservarEventosClient = grpc.ServerStreamingClient[Evento]
+
+// BibliotecaServer is the server API for Biblioteca service.
+// All implementations must embed UnimplementedBibliotecaServer
+// for forward compatibility.
+type BibliotecaServer interface {
+	// Libros
+	AgregarLibro(context.Context, *AgregarLibroRequest) (*Libro, error)
+	ObtenerLibro(context.Context, *ObtenerLibroRequest) (*Libro, error)
+	ListarLibros(context.Context, *ListarLibrosRequest) (*ListarLibrosResponse, error)
+	// Usuarios
+	RegistrarUsuario(context.Context, *RegistrarUsuarioRequest) (*Usuario, error)
+	ObtenerUsuario(context.Context, *ObtenerUsuarioRequest) (*Usuario, error)
+	// Préstamos
+	PrestarLibro(context.Context, *PrestarLibroRequest) (*Prestamo, error)
+	DevolverLibro(context.Context, *DevolverLibroRequest) (*Prestamo, error)
+	RenovarPrestamo(context.Context, *RenovarPrestamoRequest) (*Prestamo, error)
+	ListarPrestamos(context.Context, *ListarPrestamosRequest) (*ListarPrestamosResponse, error)
+	// ObservarEventos emite los eventos de la biblioteca a medida que ocurren
+	ObservarEventos(*ObservarEventosRequest, grpc.ServerStreamingServer[Evento]) error
+	mustEmbedUnimplementedBibliotecaServer()
+}
+
+// UnimplementedBibliotecaServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBibliotecaServer struct{}
+
+func (UnimplementedBibliotecaServer) AgregarLibro(context.Context, *AgregarLibroRequest) (*Libro, error) {
+	return nil, status.Error(codes.Unimplemented, "method AgregarLibro not implemented")
+}
+func (UnimplementedBibliotecaServer) ObtenerLibro(context.Context, *ObtenerLibroRequest) (*Libro, error) {
+	return nil, status.Error(codes.Unimplemented, "method ObtenerLibro not implemented")
+}
+func (UnimplementedBibliotecaServer) ListarLibros(context.Context, *ListarLibrosRequest) (*ListarLibrosResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListarLibros not implemented")
+}
+func (UnimplementedBibliotecaServer) RegistrarUsuario(context.Context, *RegistrarUsuarioRequest) (*Usuario, error) {
+	return nil, status.Error(codes.Unimplemented, "method RegistrarUsuario not implemented")
+}
+func (UnimplementedBibliotecaServer) ObtenerUsuario(context.Context, *ObtenerUsuarioRequest) (*Usuario, error) {
+	return nil, status.Error(codes.Unimplemented, "method ObtenerUsuario not implemented")
+}
+func (UnimplementedBibliotecaServer) PrestarLibro(context.Context, *PrestarLibroRequest) (*Prestamo, error) {
+	return nil, status.Error(codes.Unimplemented, "method PrestarLibro not implemented")
+}
+func (UnimplementedBibliotecaServer) DevolverLibro(context.Context, *DevolverLibroRequest) (*Prestamo, error) {
+	return nil, status.Error(codes.Unimplemented, "method DevolverLibro not implemented")
+}
+func (UnimplementedBibliotecaServer) RenovarPrestamo(context.Context, *RenovarPrestamoRequest) (*Prestamo, error) {
+	return nil, status.Error(codes.Unimplemented, "method RenovarPrestamo not implemented")
+}
+func (UnimplementedBibliotecaServer) ListarPrestamos(context.Context, *ListarPrestamosRequest) (*ListarPrestamosResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListarPrestamos not implemented")
+}
+func (UnimplementedBibliotecaServer) ObservarEventos(*ObservarEventosRequest, grpc.ServerStreamingServer[Evento]) error {
+	return status.Error(codes.Unimplemented, "method ObservarEventos not implemented")
+}
+func (UnimplementedBibliotecaServer) mustEmbedUnimplementedBibliotecaServer() {}
+func (UnimplementedBibliotecaServer) testEmbeddedByValue()                    {}
+
+// UnsafeBibliotecaServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BibliotecaServer will
+// result in compilation errors.
+type UnsafeBibliotecaServer interface {
+	mustEmbedUnimplementedBibliotecaServer()
+}
+
+func RegisterBibliotecaServer(s grpc.ServiceRegistrar, srv BibliotecaServer) {
+	// If the following call panics, it indicates UnimplementedBibliotecaServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Biblioteca_ServiceDesc, srv)
+}
+
+func _Biblioteca_AgregarLibro_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgregarLibroRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BibliotecaServer).AgregarLibro(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Biblioteca_AgregarLibro_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BibliotecaServer).AgregarLibro(ctx, req.(*AgregarLibroRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Biblioteca_ObtenerLibro_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ObtenerLibroRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BibliotecaServer).ObtenerLibro(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Biblioteca_ObtenerLibro_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BibliotecaServer).ObtenerLibro(ctx, req.(*ObtenerLibroRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Biblioteca_ListarLibros_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListarLibrosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BibliotecaServer).ListarLibros(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Biblioteca_ListarLibros_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BibliotecaServer).ListarLibros(ctx, req.(*ListarLibrosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Biblioteca_RegistrarUsuario_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegistrarUsuarioRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BibliotecaServer).RegistrarUsuario(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Biblioteca_RegistrarUsuario_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BibliotecaServer).RegistrarUsuario(ctx, req.(*RegistrarUsuarioRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Biblioteca_ObtenerUsuario_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ObtenerUsuarioRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BibliotecaServer).ObtenerUsuario(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Biblioteca_ObtenerUsuario_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BibliotecaServer).ObtenerUsuario(ctx, req.(*ObtenerUsuarioRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Biblioteca_PrestarLibro_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrestarLibroRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BibliotecaServer).PrestarLibro(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Biblioteca_PrestarLibro_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BibliotecaServer).PrestarLibro(ctx, req.(*PrestarLibroRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Biblioteca_DevolverLibro_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DevolverLibroRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BibliotecaServer).DevolverLibro(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Biblioteca_DevolverLibro_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BibliotecaServer).DevolverLibro(ctx, req.(*DevolverLibroRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Biblioteca_RenovarPrestamo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenovarPrestamoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BibliotecaServer).RenovarPrestamo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Biblioteca_RenovarPrestamo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BibliotecaServer).RenovarPrestamo(ctx, req.(*RenovarPrestamoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Biblioteca_ListarPrestamos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListarPrestamosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BibliotecaServer).ListarPrestamos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Biblioteca_ListarPrestamos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BibliotecaServer).ListarPrestamos(ctx, req.(*ListarPrestamosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Biblioteca_ObservarEventos_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ObservarEventosRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BibliotecaServer).ObservarEventos(m, &grpc.GenericServerStream[ObservarEventosRequest, Evento]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Biblioteca_ObservarEventosServer = grpc.ServerStreamingServer[Evento]
+
+// Biblioteca_ServiceDesc is the grpc.ServiceDesc for Biblioteca service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Biblioteca_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "biblioteca.v1.Biblioteca",
+	HandlerType: (*BibliotecaServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AgregarLibro",
+			Handler:    _Biblioteca_AgregarLibro_Handler,
+		},
+		{
+			MethodName: "ObtenerLibro",
+			Handler:    _Biblioteca_ObtenerLibro_Handler,
+		},
+		{
+			MethodName: "ListarLibros",
+			Handler:    _Biblioteca_ListarLibros_Handler,
+		},
+		{
+			MethodName: "RegistrarUsuario",
+			Handler:    _Biblioteca_RegistrarUsuario_Handler,
+		},
+		{
+			MethodName: "ObtenerUsuario",
+			Handler:    _Biblioteca_ObtenerUsuario_Handler,
+		},
+		{
+			MethodName: "PrestarLibro",
+			Handler:    _Biblioteca_PrestarLibro_Handler,
+		},
+		{
+			MethodName: "DevolverLibro",
+			Handler:    _Biblioteca_DevolverLibro_Handler,
+		},
+		{
+			MethodName: "RenovarPrestamo",
+			Handler:    _Biblioteca_RenovarPrestamo_Handler,
+		},
+		{
+			MethodName: "ListarPrestamos",
+			Handler:    _Biblioteca_ListarPrestamos_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ObservarEventos",
+			Handler:       _Biblioteca_ObservarEventos_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "bibliotecapb/biblioteca.proto",
+}
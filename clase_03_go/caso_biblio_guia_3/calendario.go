@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+)
+
+// ==========================================
+// CALENDARIO DE APERTURA, FERIADOS Y CIERRES
+// ==========================================
+
+const (
+	formatoFecha    = "2006-01-02"
+	formatoAnual    = "01-02"
+	maxDiasBusqueda = 366 // evita ciclos infinitos si nunca se abre
+)
+
+// Calendario define qué días abre la biblioteca
+type Calendario struct {
+	DiasApertura map[time.Weekday]bool
+	Feriados     map[string]string // "2006-01-02" o "01-02" (todos los años) -> nombre
+	Cierres      map[string]string // cierres puntuales: "2006-01-02" -> motivo
+}
+
+// NuevoCalendario crea un calendario que abre los días indicados;
+// sin días, abre de lunes a sábado
+func NuevoCalendario(dias ...time.Weekday) *Calendario {
+	if len(dias) == 0 {
+		dias = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday,
+			time.Thursday, time.Friday, time.Saturday}
+	}
+	c := &Calendario{
+		DiasApertura: make(map[time.Weekday]bool),
+		Feriados:     make(map[string]string),
+		Cierres:      make(map[string]string),
+	}
+	for _, dia := range dias {
+		c.DiasApertura[dia] = true
+	}
+	return c
+}
+
+// AgregarFeriado registra un feriado en una fecha concreta
+func (c *Calendario) AgregarFeriado(fecha time.Time, nombre string) {
+	c.Feriados[fecha.Format(formatoFecha)] = nombre
+}
+
+// AgregarFeriadoAnual registra un feriado que se repite cada año
+func (c *Calendario) AgregarFeriadoAnual(mes time.Month, dia int, nombre string) {
+	c.Feriados[fmt.Sprintf("%02d-%02d", int(mes), dia)] = nombre
+}
+
+// AgregarCierre registra un cierre puntual (obras, inventario, etc.)
+func (c *Calendario) AgregarCierre(fecha time.Time, motivo string) {
+	c.Cierres[fecha.Format(formatoFecha)] = motivo
+}
+
+// CargarFeriados lee un archivo con un feriado por línea:
+//
+//	2026-04-03 Viernes Santo
+//	12-25 Navidad
+//
+// Las líneas vacías y las que empiezan con # se ignoran.
+func (c *Calendario) CargarFeriados(ruta string) error {
+	archivo, err := os.Open(ruta)
+	if err != nil {
+		return fmt.Errorf("No se pudo abrir el archivo de feriados: %w", err)
+	}
+	defer archivo.Close()
+
+	scanner := bufio.NewScanner(archivo)
+	numeroLinea := 0
+	for scanner.Scan() {
+		numeroLinea++
+		linea := strings.TrimSpace(scanner.Text())
+		if linea == "" || strings.HasPrefix(linea, "#") {
+			continue
+		}
+		fecha, nombre, _ := strings.Cut(linea, " ")
+		nombre = strings.TrimSpace(nombre)
+		if t, err := time.Parse(formatoFecha, fecha); err == nil {
+			c.AgregarFeriado(t, nombre)
+		} else if t, err := time.Parse(formatoAnual, fecha); err == nil {
+			c.AgregarFeriadoAnual(t.Month(), t.Day(), nombre)
+		} else {
+			return fmt.Errorf("Fecha no válida '%s' en la línea %d", fecha, numeroLinea)
+		}
+	}
+	return scanner.Err()
+}
+
+// EstaAbierto indica si la biblioteca atiende en la fecha dada
+func (c Calendario) EstaAbierto(fecha time.Time) bool {
+	if !c.DiasApertura[fecha.Weekday()] {
+		return false
+	}
+	if _, cerrado := c.Cierres[fecha.Format(formatoFecha)]; cerrado {
+		return false
+	}
+	if _, feriado := c.Feriados[fecha.Format(formatoFecha)]; feriado {
+		return false
+	}
+	_, feriadoAnual := c.Feriados[fecha.Format(formatoAnual)]
+	return !feriadoAnual
+}
+
+// SiguienteDiaAbierto retorna la misma fecha si está abierto o el
+// siguiente día de atención
+func (c Calendario) SiguienteDiaAbierto(fecha time.Time) time.Time {
+	for i := 0; i < maxDiasBusqueda; i++ {
+		if c.EstaAbierto(fecha) {
+			return fecha
+		}
+		fecha = fecha.AddDate(0, 0, 1)
+	}
+	return fecha
+}
+
+// DiasAbiertosEntre cuenta los días de atención en el intervalo (desde, hasta]
+func (c Calendario) DiasAbiertosEntre(desde, hasta time.Time) int {
+	dias := 0
+	desde = inicioDelDia(desde)
+	hasta = inicioDelDia(hasta)
+	for dia := desde.AddDate(0, 0, 1); !dia.After(hasta); dia = dia.AddDate(0, 0, 1) {
+		if c.EstaAbierto(dia) {
+			dias++
+		}
+	}
+	return dias
+}
+
+// calcularVencimiento suma el plazo y mueve la fecha al siguiente día abierto
+func (b Biblioteca) calcularVencimiento(desde time.Time, dias int) time.Time {
+	vencimiento := desde.AddDate(0, 0, dias)
+	if b.Calendario == nil {
+		return vencimiento
+	}
+	return b.Calendario.SiguienteDiaAbierto(vencimiento)
+}
+
+// CalcularMultaRetraso retorna la multa de un préstamo devuelto en la
+// fecha indicada; solo se cobran los días en que la biblioteca abrió
+func (b Biblioteca) CalcularMultaRetraso(prestamo Prestamo, fecha time.Time) float64 {
+	if !inicioDelDia(fecha).After(inicioDelDia(prestamo.FechaDevolucion)) {
+		return 0
+	}
+	dias := int(math.Round(inicioDelDia(fecha).Sub(inicioDelDia(prestamo.FechaDevolucion)).Hours() / 24))
+	if b.Calendario != nil {
+		dias = b.Calendario.DiasAbiertosEntre(prestamo.FechaDevolucion, fecha)
+	}
+	// La tarifa quedó fijada por la política al prestar; 0 es una
+	// categoría exenta de multas, no "usar la tarifa de la biblioteca"
+	return float64(dias) * prestamo.MultaDiaria
+}
+
+func inicioDelDia(fecha time.Time) time.Time {
+	return time.Date(fecha.Year(), fecha.Month(), fecha.Day(), 0, 0, 0, 0, fecha.Location())
+}
@@ -0,0 +1,291 @@
+package codigobarras
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+)
+
+// ==========================================
+// PASO 1: TIPOS BÁSICOS
+// ==========================================
+
+// Simbologia identifica el tipo de código de barras
+type Simbologia string
+
+const (
+	SimbologiaEAN13   Simbologia = "EAN-13"
+	SimbologiaCode128 Simbologia = "Code 128"
+)
+
+// Codigo es un código de barras ya codificado en módulos.
+// Cada elemento de Modulos es una barra (true) o un espacio (false)
+// del ancho mínimo.
+type Codigo struct {
+	Simbologia Simbologia
+	Texto      string
+	Modulos    []bool
+}
+
+// zonaSilencio es el margen en blanco (en módulos) a cada lado
+const zonaSilencio = 10
+
+// ==========================================
+// PASO 2: EAN-13
+// ==========================================
+
+var (
+	eanL = [10]string{"0001101", "0011001", "0010011", "0111101", "0100011",
+		"0110001", "0101111", "0111011", "0110111", "0001011"}
+	eanG = [10]string{"0100111", "0110011", "0011011", "0100001", "0011101",
+		"0111001", "0000101", "0010001", "0001001", "0010111"}
+	eanR = [10]string{"1110010", "1100110", "1101100", "1000010", "1011100",
+		"1001110", "1010000", "1000100", "1001000", "1110100"}
+	// paridad de los seis primeros dígitos según el dígito inicial
+	eanParidad = [10]string{"LLLLLL", "LLGLGG", "LLGGLG", "LLGGGL", "LGLLGG",
+		"LGGLLG", "LGGGLL", "LGLGLG", "LGLGGL", "LGGLGL"}
+)
+
+// EAN13 codifica 12 o 13 dígitos; si son 12 se calcula el dígito de control
+func EAN13(digitos string) (Codigo, error) {
+	if !soloDigitos(digitos) || (len(digitos) != 12 && len(digitos) != 13) {
+		return Codigo{}, fmt.Errorf("EAN-13 requiere 12 o 13 dígitos: '%s'", digitos)
+	}
+	control := digitoControlEAN(digitos[:12])
+	if len(digitos) == 13 && int(digitos[12]-'0') != control {
+		return Codigo{}, fmt.Errorf("dígito de control inválido en '%s'", digitos)
+	}
+	digitos = digitos[:12] + string(rune('0'+control))
+
+	var patron strings.Builder
+	patron.WriteString("101")
+	paridad := eanParidad[digitos[0]-'0']
+	for i := 1; i <= 6; i++ {
+		d := digitos[i] - '0'
+		if paridad[i-1] == 'L' {
+			patron.WriteString(eanL[d])
+		} else {
+			patron.WriteString(eanG[d])
+		}
+	}
+	patron.WriteString("01010")
+	for i := 7; i <= 12; i++ {
+		patron.WriteString(eanR[digitos[i]-'0'])
+	}
+	patron.WriteString("101")
+
+	return Codigo{Simbologia: SimbologiaEAN13, Texto: digitos, Modulos: aModulos(patron.String())}, nil
+}
+
+// ISBNaEAN13 normaliza un ISBN-10 o ISBN-13 (con o sin guiones) a sus 13 dígitos
+func ISBNaEAN13(isbn string) (string, error) {
+	limpio := strings.NewReplacer("-", "", " ", "").Replace(strings.ToUpper(isbn))
+	switch len(limpio) {
+	case 13:
+		if !soloDigitos(limpio) {
+			return "", fmt.Errorf("ISBN-13 inválido: '%s'", isbn)
+		}
+		if int(limpio[12]-'0') != digitoControlEAN(limpio[:12]) {
+			return "", fmt.Errorf("dígito de control inválido en el ISBN '%s'", isbn)
+		}
+		return limpio, nil
+	case 10:
+		if !soloDigitos(limpio[:9]) || !(soloDigitos(limpio[9:]) || limpio[9] == 'X') {
+			return "", fmt.Errorf("ISBN-10 inválido: '%s'", isbn)
+		}
+		if !controlISBN10Valido(limpio) {
+			return "", fmt.Errorf("dígito de control inválido en el ISBN '%s'", isbn)
+		}
+		base := "978" + limpio[:9]
+		return base + string(rune('0'+digitoControlEAN(base))), nil
+	}
+	return "", fmt.Errorf("ISBN debe tener 10 o 13 dígitos: '%s'", isbn)
+}
+
+// controlISBN10Valido verifica la suma ponderada módulo 11; la X vale 10
+func controlISBN10Valido(diez string) bool {
+	suma := 0
+	for i := 0; i < 10; i++ {
+		d := int(diez[i] - '0')
+		if diez[i] == 'X' {
+			d = 10
+		}
+		suma += d * (10 - i)
+	}
+	return suma%11 == 0
+}
+
+func digitoControlEAN(doce string) int {
+	suma := 0
+	for i, c := range doce {
+		d := int(c - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		suma += d
+	}
+	return (10 - suma%10) % 10
+}
+
+// ==========================================
+// PASO 3: CODE 128
+// ==========================================
+
+// anchos de barras y espacios de cada símbolo Code 128 (0 a 106)
+var code128Patrones = [107]string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232", "2331112",
+}
+
+const (
+	code128InicioB = 104
+	code128InicioC = 105
+	code128Parada  = 106
+)
+
+// Code128 codifica texto ASCII imprimible. Las cadenas de dígitos de
+// longitud par usan el subconjunto C, que es más compacto.
+func Code128(texto string) (Codigo, error) {
+	if texto == "" {
+		return Codigo{}, errors.New("Code 128 requiere texto")
+	}
+
+	var simbolos []int
+	if soloDigitos(texto) && len(texto)%2 == 0 {
+		simbolos = append(simbolos, code128InicioC)
+		for i := 0; i < len(texto); i += 2 {
+			simbolos = append(simbolos, int(texto[i]-'0')*10+int(texto[i+1]-'0'))
+		}
+	} else {
+		simbolos = append(simbolos, code128InicioB)
+		for _, c := range texto {
+			if c < 32 || c > 126 {
+				return Codigo{}, fmt.Errorf("carácter no soportado en Code 128: %q", c)
+			}
+			simbolos = append(simbolos, int(c)-32)
+		}
+	}
+
+	control := simbolos[0]
+	for i, s := range simbolos[1:] {
+		control += s * (i + 1)
+	}
+	simbolos = append(simbolos, control%103, code128Parada)
+
+	var patron strings.Builder
+	for _, s := range simbolos {
+		barra := true
+		for _, ancho := range code128Patrones[s] {
+			caracter := "0"
+			if barra {
+				caracter = "1"
+			}
+			patron.WriteString(strings.Repeat(caracter, int(ancho-'0')))
+			barra = !barra
+		}
+	}
+
+	return Codigo{Simbologia: SimbologiaCode128, Texto: texto, Modulos: aModulos(patron.String())}, nil
+}
+
+// ==========================================
+// PASO 4: RENDERIZADO SVG Y PNG
+// ==========================================
+
+// Ancho retorna el ancho total en módulos, incluyendo la zona de silencio
+func (c Codigo) Ancho() int {
+	return len(c.Modulos) + 2*zonaSilencio
+}
+
+// Rects retorna las barras como elementos <rect> para incrustar en otro SVG
+func (c Codigo) Rects(x, y, anchoModulo, alto float64) string {
+	var sb strings.Builder
+	x += zonaSilencio * anchoModulo
+	for i := 0; i < len(c.Modulos); {
+		if !c.Modulos[i] {
+			i++
+			continue
+		}
+		inicio := i
+		for i < len(c.Modulos) && c.Modulos[i] {
+			i++
+		}
+		fmt.Fprintf(&sb, `<rect x="%.3f" y="%.3f" width="%.3f" height="%.3f"/>`,
+			x+float64(inicio)*anchoModulo, y, float64(i-inicio)*anchoModulo, alto)
+	}
+	return sb.String()
+}
+
+// SVG retorna un documento SVG con el código y su texto legible
+func (c Codigo) SVG(anchoModulo, alto float64) string {
+	ancho := float64(c.Ancho()) * anchoModulo
+	altoTexto := alto * 0.2
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%.3f" height="%.3f" viewBox="0 0 %.3f %.3f">`+
+		`<rect width="100%%" height="100%%" fill="white"/><g fill="black">%s</g>`+
+		`<text x="%.3f" y="%.3f" font-family="monospace" font-size="%.3f" text-anchor="middle">%s</text></svg>`,
+		ancho, alto+altoTexto, ancho, alto+altoTexto,
+		c.Rects(0, 0, anchoModulo, alto),
+		ancho/2, alto+altoTexto*0.85, altoTexto*0.8, EscaparXML(c.Texto))
+}
+
+// PNG escribe el código como imagen en escala de grises
+func (c Codigo) PNG(w io.Writer, escala, alto int) error {
+	if escala <= 0 || alto <= 0 {
+		return errors.New("escala y alto deben ser positivos")
+	}
+	img := image.NewGray(image.Rect(0, 0, c.Ancho()*escala, alto))
+	for y := 0; y < alto; y++ {
+		for x := 0; x < c.Ancho()*escala; x++ {
+			img.SetGray(x, y, color.Gray{Y: 255})
+		}
+	}
+	for i, barra := range c.Modulos {
+		if !barra {
+			continue
+		}
+		for dx := 0; dx < escala; dx++ {
+			for y := 0; y < alto; y++ {
+				img.SetGray((zonaSilencio+i)*escala+dx, y, color.Gray{Y: 0})
+			}
+		}
+	}
+	return png.Encode(w, img)
+}
+
+// EscaparXML protege el texto que se incrusta en un SVG
+func EscaparXML(texto string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "'", "&apos;").Replace(texto)
+}
+
+func aModulos(patron string) []bool {
+	modulos := make([]bool, len(patron))
+	for i, c := range patron {
+		modulos[i] = c == '1'
+	}
+	return modulos
+}
+
+func soloDigitos(texto string) bool {
+	if texto == "" {
+		return false
+	}
+	for _, c := range texto {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
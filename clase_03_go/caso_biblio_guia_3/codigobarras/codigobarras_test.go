@@ -0,0 +1,165 @@
+package codigobarras
+
+import (
+	"strings"
+	"testing"
+)
+
+// patron convierte los módulos en una cadena de 1 y 0
+func patron(c Codigo) string {
+	var sb strings.Builder
+	for _, barra := range c.Modulos {
+		if barra {
+			sb.WriteByte('1')
+		} else {
+			sb.WriteByte('0')
+		}
+	}
+	return sb.String()
+}
+
+// desdeAnchos arma el patrón a partir de anchos alternados barra/espacio
+func desdeAnchos(simbolos ...string) string {
+	var sb strings.Builder
+	for _, simbolo := range simbolos {
+		barra := true
+		for _, ancho := range simbolo {
+			caracter := "0"
+			if barra {
+				caracter = "1"
+			}
+			sb.WriteString(strings.Repeat(caracter, int(ancho-'0')))
+			barra = !barra
+		}
+	}
+	return sb.String()
+}
+
+func TestEAN13DigitoDeControl(t *testing.T) {
+	casos := []struct {
+		entrada string
+		texto   string
+		valido  bool
+	}{
+		{"978030640615", "9780306406157", true},
+		{"590123412345", "5901234123457", true},
+		{"9780306406157", "9780306406157", true},
+		{"9780306406158", "", false},
+		{"97803064061", "", false},
+		{"97803064061a", "", false},
+	}
+	for _, caso := range casos {
+		codigo, err := EAN13(caso.entrada)
+		if (err == nil) != caso.valido {
+			t.Errorf("EAN13(%q): error %v, se esperaba válido = %v", caso.entrada, err, caso.valido)
+			continue
+		}
+		if caso.valido && codigo.Texto != caso.texto {
+			t.Errorf("EAN13(%q).Texto = %q, se esperaba %q", caso.entrada, codigo.Texto, caso.texto)
+		}
+	}
+}
+
+func TestEAN13Patron(t *testing.T) {
+	// Las tablas L, G y R están relacionadas: R es el complemento de L y
+	// G es R al revés
+	for d := range 10 {
+		var complemento, reves strings.Builder
+		for i := range 7 {
+			if eanL[d][i] == '0' {
+				complemento.WriteByte('1')
+			} else {
+				complemento.WriteByte('0')
+			}
+			reves.WriteByte(eanR[d][6-i])
+		}
+		if eanR[d] != complemento.String() || eanG[d] != reves.String() {
+			t.Errorf("tablas EAN inconsistentes para el dígito %d", d)
+		}
+	}
+
+	codigo, err := EAN13("5901234123457")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 5 → paridad LGGLLG para 901234; la mitad derecha 123457 va en R
+	esperado := "101" +
+		eanL[9] + eanG[0] + eanG[1] + eanL[2] + eanL[3] + eanG[4] +
+		"01010" +
+		eanR[1] + eanR[2] + eanR[3] + eanR[4] + eanR[5] + eanR[7] +
+		"101"
+	if got := patron(codigo); got != esperado {
+		t.Errorf("patrón EAN-13:\n%s\nse esperaba:\n%s", got, esperado)
+	}
+	if len(codigo.Modulos) != 95 || codigo.Ancho() != 95+2*zonaSilencio {
+		t.Errorf("EAN-13 con %d módulos, se esperaban 95", len(codigo.Modulos))
+	}
+}
+
+func TestISBNaEAN13(t *testing.T) {
+	casos := []struct {
+		isbn   string
+		ean    string
+		valido bool
+	}{
+		{"0-306-40615-2", "9780306406157", true},
+		{"080442957X", "9780804429573", true},
+		{"0-8044-2957-x", "9780804429573", true},
+		{"978-0-306-40615-7", "9780306406157", true},
+		{"0-306-40615-3", "", false}, // dígito de control equivocado
+		{"0-306-40615-X", "", false},
+		{"978-0-306-40615-8", "", false},
+		{"X306406152", "", false},
+		{"12345", "", false},
+	}
+	for _, caso := range casos {
+		ean, err := ISBNaEAN13(caso.isbn)
+		if (err == nil) != caso.valido {
+			t.Errorf("ISBNaEAN13(%q): error %v, se esperaba válido = %v", caso.isbn, err, caso.valido)
+			continue
+		}
+		if ean != caso.ean {
+			t.Errorf("ISBNaEAN13(%q) = %q, se esperaba %q", caso.isbn, ean, caso.ean)
+		}
+	}
+}
+
+func TestCode128(t *testing.T) {
+	for i, p := range code128Patrones {
+		ancho := 0
+		for _, c := range p {
+			ancho += int(c - '0')
+		}
+		if (i == code128Parada && ancho != 13) || (i != code128Parada && ancho != 11) {
+			t.Errorf("el símbolo %d mide %d módulos", i, ancho)
+		}
+	}
+
+	casos := []struct {
+		texto    string
+		simbolos []string
+	}{
+		// Inicio B, 'A' (33), 'B' (34), control (104+33+2·34) % 103 = 102, parada
+		{"AB", []string{"211214", "111323", "131123", "411131", "2331112"}},
+		// Inicio C, 12, 34, control (105+12+2·34) % 103 = 82, parada
+		{"1234", []string{"211232", "112232", "131123", "121241", "2331112"}},
+		// Una cantidad impar de dígitos va en el subconjunto B:
+		// '1' (17), '2' (18), '3' (19), control (104+17+36+57) % 103 = 8
+		{"123", []string{"211214", "123221", "223211", "221132", "132212", "2331112"}},
+	}
+	for _, caso := range casos {
+		codigo, err := Code128(caso.texto)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, esperado := patron(codigo), desdeAnchos(caso.simbolos...); got != esperado {
+			t.Errorf("Code128(%q):\n%s\nse esperaba:\n%s", caso.texto, got, esperado)
+		}
+	}
+
+	for _, texto := range []string{"", "año"} {
+		if _, err := Code128(texto); err == nil {
+			t.Errorf("Code128(%q): se esperaba un error", texto)
+		}
+	}
+}
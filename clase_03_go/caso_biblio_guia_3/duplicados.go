@@ -0,0 +1,260 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ==========================================
+// DETECCIÓN Y FUSIÓN DE DUPLICADOS
+// ==========================================
+
+// umbralSimilitudLibros es la similitud mínima de título y autor para
+// considerar dos libros sin ISBN como posible duplicado
+const umbralSimilitudLibros = 0.85
+
+// ParDuplicado son dos registros que probablemente son el mismo
+type ParDuplicado struct {
+	ID1       int
+	ID2       int
+	Similitud float64 // 1.0 = idénticos tras normalizar
+	Motivo    string
+}
+
+var sinAcentos = strings.NewReplacer(
+	"á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u", "ñ", "n",
+	"à", "a", "è", "e", "ì", "i", "ò", "o", "ù", "u", "â", "a", "ê", "e",
+	"ô", "o", "ã", "a", "õ", "o", "ç", "c",
+)
+
+// normalizarEmail pasa a minúsculas y, en Gmail, quita los puntos y la
+// etiqueta "+algo" de la parte local, que el proveedor ignora
+func normalizarEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	local, dominio, ok := strings.Cut(email, "@")
+	if !ok {
+		return email
+	}
+	if dominio == "gmail.com" || dominio == "googlemail.com" {
+		local, _, _ = strings.Cut(local, "+")
+		local = strings.ReplaceAll(local, ".", "")
+		dominio = "gmail.com"
+	}
+	return local + "@" + dominio
+}
+
+// normalizarTelefono deja solo los dígitos
+func normalizarTelefono(telefono string) string {
+	var sb strings.Builder
+	for _, r := range telefono {
+		if unicode.IsDigit(r) {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// normalizarISBN quita guiones y espacios
+func normalizarISBN(isbn string) string {
+	return strings.NewReplacer("-", "", " ", "").Replace(strings.ToUpper(strings.TrimSpace(isbn)))
+}
+
+// normalizarTexto quita acentos, signos y espacios repetidos
+func normalizarTexto(texto string) string {
+	texto = sinAcentos.Replace(strings.ToLower(texto))
+	var sb strings.Builder
+	for _, r := range texto {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+// similitud retorna 1 - distancia de Levenshtein / longitud mayor
+func similitud(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 && len(rb) == 0 {
+		return 1
+	}
+	anterior := make([]int, len(rb)+1)
+	actual := make([]int, len(rb)+1)
+	for j := range anterior {
+		anterior[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		actual[0] = i
+		for j := 1; j <= len(rb); j++ {
+			costo := 1
+			if ra[i-1] == rb[j-1] {
+				costo = 0
+			}
+			actual[j] = min(anterior[j]+1, actual[j-1]+1, anterior[j-1]+costo)
+		}
+		anterior, actual = actual, anterior
+	}
+	return 1 - float64(anterior[len(rb)])/float64(max(len(ra), len(rb)))
+}
+
+// BuscarUsuariosDuplicados encuentra usuarios con el mismo email o
+// teléfono una vez normalizados
+func (b Biblioteca) BuscarUsuariosDuplicados() []ParDuplicado {
+	pares := make([]ParDuplicado, 0)
+	for i := 0; i < len(b.Usuarios); i++ {
+		for j := i + 1; j < len(b.Usuarios); j++ {
+			u1, u2 := b.Usuarios[i], b.Usuarios[j]
+			if u1.Anonimizado || u2.Anonimizado {
+				continue
+			}
+			switch {
+			case u1.Email != "" && normalizarEmail(u1.Email) == normalizarEmail(u2.Email):
+				pares = append(pares, ParDuplicado{ID1: u1.ID, ID2: u2.ID, Similitud: 1, Motivo: "mismo email"})
+			case len(normalizarTelefono(u1.Telefono)) >= 7 &&
+				normalizarTelefono(u1.Telefono) == normalizarTelefono(u2.Telefono) &&
+				normalizarTexto(u1.Nombre) == normalizarTexto(u2.Nombre):
+				pares = append(pares, ParDuplicado{ID1: u1.ID, ID2: u2.ID, Similitud: 1, Motivo: "mismo nombre y teléfono"})
+			}
+		}
+	}
+	return pares
+}
+
+// BuscarLibrosDuplicados encuentra libros con el mismo ISBN o, si alguno
+// no tiene ISBN, con título y autor muy parecidos
+func (b Biblioteca) BuscarLibrosDuplicados() []ParDuplicado {
+	pares := make([]ParDuplicado, 0)
+	for i := 0; i < len(b.Libros); i++ {
+		for j := i + 1; j < len(b.Libros); j++ {
+			l1, l2 := b.Libros[i], b.Libros[j]
+			if l1.Estado == DadoDeBaja || l2.Estado == DadoDeBaja {
+				continue
+			}
+			isbn1, isbn2 := normalizarISBN(l1.ISBN), normalizarISBN(l2.ISBN)
+			if isbn1 != "" && isbn1 == isbn2 {
+				pares = append(pares, ParDuplicado{ID1: l1.ID, ID2: l2.ID, Similitud: 1, Motivo: "mismo ISBN"})
+				continue
+			}
+			if isbn1 != "" && isbn2 != "" {
+				continue // ISBN distintos: ediciones diferentes
+			}
+			s := similitud(normalizarTexto(l1.Titulo+" "+l1.Autor), normalizarTexto(l2.Titulo+" "+l2.Autor))
+			if s >= umbralSimilitudLibros {
+				pares = append(pares, ParDuplicado{ID1: l1.ID, ID2: l2.ID, Similitud: s, Motivo: "título y autor parecidos"})
+			}
+		}
+	}
+	sort.SliceStable(pares, func(i, j int) bool { return pares[i].Similitud > pares[j].Similitud })
+	return pares
+}
+
+// FusionarUsuarios traspasa préstamos y multas del usuario duplicado al
+// que se conserva, completa los datos que le falten y elimina el duplicado
+func (b *Biblioteca) FusionarUsuarios(conservarID, eliminarID int) error {
+	if conservarID == eliminarID {
+		return nuevoError(CodigoOperacionInvalida, "usuario", eliminarID, "id", fmt.Sprint(eliminarID),
+			"No se puede fusionar un usuario consigo mismo")
+	}
+	conservar := b.BuscarUsuario(conservarID)
+	if conservar == nil {
+		return errUsuarioNoEncontrado(conservarID)
+	}
+	eliminar := b.BuscarUsuario(eliminarID)
+	if eliminar == nil {
+		return errUsuarioNoEncontrado(eliminarID)
+	}
+	// Fusionar un usuario anonimizado volvería a unir sus préstamos y
+	// multas con datos personales
+	for _, usuario := range []*Usuario{conservar, eliminar} {
+		if usuario.Anonimizado {
+			return nuevoError(CodigoOperacionInvalida, "usuario", usuario.ID, "anonimizado", "true",
+				"El usuario '%d' fue anonimizado y no se puede fusionar", usuario.ID)
+		}
+	}
+
+	if conservar.Telefono == "" {
+		conservar.Telefono = eliminar.Telefono
+	}
+	if conservar.Idioma == "" {
+		conservar.Idioma = eliminar.Idioma
+	}
+	if conservar.Categoria == "" {
+		conservar.Categoria = eliminar.Categoria
+	}
+	conservar.Activo = conservar.Activo || eliminar.Activo
+
+	for i := range b.Prestamos {
+		if b.Prestamos[i].UsuarioID == eliminarID {
+			b.Prestamos[i].UsuarioID = conservarID
+		}
+	}
+	for i := range b.Multas {
+		if b.Multas[i].UsuarioID == eliminarID {
+			b.Multas[i].UsuarioID = conservarID
+		}
+	}
+
+	for i := range b.Usuarios {
+		if b.Usuarios[i].ID == eliminarID {
+			b.Usuarios = append(b.Usuarios[:i], b.Usuarios[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// FusionarLibros traspasa el historial del libro duplicado al que se
+// conserva y elimina el duplicado. No se permite si ambos están prestados
+// ni si el préstamo del duplicado quedaría sobre un libro fuera de circulación.
+func (b *Biblioteca) FusionarLibros(conservarID, eliminarID int) error {
+	if conservarID == eliminarID {
+		return nuevoError(CodigoOperacionInvalida, "libro", eliminarID, "id", fmt.Sprint(eliminarID),
+			"No se puede fusionar un libro consigo mismo")
+	}
+	conservar := b.BuscarLibro(conservarID)
+	if conservar == nil {
+		return errLibroNoEncontrado(conservarID)
+	}
+	eliminar := b.BuscarLibro(eliminarID)
+	if eliminar == nil {
+		return errLibroNoEncontrado(eliminarID)
+	}
+	if conservar.Prestado && eliminar.Prestado {
+		return nuevoError(CodigoOperacionInvalida, "libro", eliminarID, "prestado", "true",
+			"Los libros '%d' y '%d' están prestados a la vez", conservarID, eliminarID)
+	}
+	if eliminar.Prestado && conservar.Estado != EnCirculacion {
+		return nuevoError(CodigoOperacionInvalida, "libro", conservarID, "estado", string(conservar.Estado),
+			"El libro '%d' está %s y no puede recibir el préstamo de '%d'", conservarID, conservar.Estado, eliminarID)
+	}
+
+	if conservar.ISBN == "" {
+		conservar.ISBN = eliminar.ISBN
+	}
+	if conservar.Categoria == "" {
+		conservar.Categoria = eliminar.Categoria
+	}
+	if conservar.Ubicacion == "" {
+		conservar.Ubicacion = eliminar.Ubicacion
+	}
+	if eliminar.Prestado {
+		conservar.Prestado = true
+	}
+
+	for i := range b.Prestamos {
+		if b.Prestamos[i].LibroID == eliminarID {
+			b.Prestamos[i].LibroID = conservarID
+		}
+	}
+
+	for i := range b.Libros {
+		if b.Libros[i].ID == eliminarID {
+			b.Libros = append(b.Libros[:i], b.Libros[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
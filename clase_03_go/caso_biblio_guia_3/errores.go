@@ -0,0 +1,220 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ==========================================
+// ERRORES TIPADOS DE LA BIBLIOTECA
+// ==========================================
+
+// CodigoError es un identificador estable de cada tipo de fallo.
+// Los códigos no cambian aunque cambie el texto del mensaje.
+type CodigoError string
+
+const (
+	CodigoDatoRequerido        CodigoError = "DATO_REQUERIDO"
+	CodigoDatoInvalido         CodigoError = "DATO_INVALIDO"
+	CodigoDuplicado            CodigoError = "DUPLICADO"
+	CodigoLibroNoEncontrado    CodigoError = "LIBRO_NO_ENCONTRADO"
+	CodigoUsuarioNoEncontrado  CodigoError = "USUARIO_NO_ENCONTRADO"
+	CodigoPrestamoNoEncontrado CodigoError = "PRESTAMO_NO_ENCONTRADO"
+	CodigoMultaNoEncontrada    CodigoError = "MULTA_NO_ENCONTRADA"
+	CodigoUsuarioNoHabilitado  CodigoError = "USUARIO_NO_HABILITADO"
+	CodigoLibroYaPrestado      CodigoError = "LIBRO_YA_PRESTADO"
+	CodigoLibroNoPrestado      CodigoError = "LIBRO_NO_PRESTADO"
+	CodigoLibroNoDisponible    CodigoError = "LIBRO_NO_DISPONIBLE"
+	CodigoOperacionInvalida    CodigoError = "OPERACION_INVALIDA"
+	CodigoPrestamoDenegado     CodigoError = "PRESTAMO_DENEGADO"
+)
+
+// ErrorBiblioteca describe un fallo de negocio con su código, la entidad
+// afectada y, si aplica, el campo y valor que lo provocaron
+type ErrorBiblioteca struct {
+	Codigo  CodigoError
+	Entidad string // "libro", "usuario", "prestamo", "multa"...
+	ID      int
+	Campo   string
+	Valor   string
+	Mensaje string
+}
+
+// Errores centinela para usar con errors.Is; comparan solo el código
+var (
+	ErrDatoRequerido        = &ErrorBiblioteca{Codigo: CodigoDatoRequerido}
+	ErrDatoInvalido         = &ErrorBiblioteca{Codigo: CodigoDatoInvalido}
+	ErrDuplicado            = &ErrorBiblioteca{Codigo: CodigoDuplicado}
+	ErrLibroNoEncontrado    = &ErrorBiblioteca{Codigo: CodigoLibroNoEncontrado}
+	ErrUsuarioNoEncontrado  = &ErrorBiblioteca{Codigo: CodigoUsuarioNoEncontrado}
+	ErrPrestamoNoEncontrado = &ErrorBiblioteca{Codigo: CodigoPrestamoNoEncontrado}
+	ErrMultaNoEncontrada    = &ErrorBiblioteca{Codigo: CodigoMultaNoEncontrada}
+	ErrUsuarioNoHabilitado  = &ErrorBiblioteca{Codigo: CodigoUsuarioNoHabilitado}
+	ErrLibroYaPrestado      = &ErrorBiblioteca{Codigo: CodigoLibroYaPrestado}
+	ErrLibroNoPrestado      = &ErrorBiblioteca{Codigo: CodigoLibroNoPrestado}
+	ErrLibroNoDisponible    = &ErrorBiblioteca{Codigo: CodigoLibroNoDisponible}
+	ErrOperacionInvalida    = &ErrorBiblioteca{Codigo: CodigoOperacionInvalida}
+	ErrPrestamoDenegado     = &ErrorBiblioteca{Codigo: CodigoPrestamoDenegado}
+)
+
+// Error implementa la interfaz error con el mensaje original en español
+func (e *ErrorBiblioteca) Error() string {
+	if e.Mensaje != "" {
+		return e.Mensaje
+	}
+	return string(e.Codigo)
+}
+
+// Is permite errors.Is(err, ErrLibroNoEncontrado) comparando por código
+func (e *ErrorBiblioteca) Is(target error) bool {
+	t, ok := target.(*ErrorBiblioteca)
+	return ok && t.Codigo == e.Codigo
+}
+
+// EstadoHTTP retorna el código HTTP equivalente a cada código de error
+func (c CodigoError) EstadoHTTP() int {
+	switch c {
+	case CodigoDatoRequerido, CodigoDatoInvalido:
+		return http.StatusBadRequest
+	case CodigoLibroNoEncontrado, CodigoUsuarioNoEncontrado,
+		CodigoPrestamoNoEncontrado, CodigoMultaNoEncontrada:
+		return http.StatusNotFound
+	case CodigoUsuarioNoHabilitado, CodigoPrestamoDenegado:
+		return http.StatusForbidden
+	case CodigoDuplicado, CodigoLibroYaPrestado, CodigoLibroNoPrestado,
+		CodigoLibroNoDisponible, CodigoOperacionInvalida:
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// mensajesError traduce cada código; {id}, {campo} y {valor} se
+// reemplazan con los datos del error
+var mensajesError = map[CodigoError]map[Idioma]string{
+	CodigoDatoRequerido: {
+		"es": "Falta el dato obligatorio '{campo}'",
+		"en": "Required field '{campo}' is missing",
+		"pt": "O campo obrigatório '{campo}' está faltando",
+	},
+	CodigoDatoInvalido: {
+		"es": "El valor '{valor}' no es válido para '{campo}'",
+		"en": "Value '{valor}' is not valid for '{campo}'",
+		"pt": "O valor '{valor}' não é válido para '{campo}'",
+	},
+	CodigoDuplicado: {
+		"es": "Ya existe un registro con {campo} '{valor}'",
+		"en": "A record with {campo} '{valor}' already exists",
+		"pt": "Já existe um registro com {campo} '{valor}'",
+	},
+	CodigoLibroNoEncontrado: {
+		"es": "No existe un libro con ID '{id}'",
+		"en": "There is no book with ID '{id}'",
+		"pt": "Não existe um livro com ID '{id}'",
+	},
+	CodigoUsuarioNoEncontrado: {
+		"es": "No existe un usuario con ID '{id}'",
+		"en": "There is no user with ID '{id}'",
+		"pt": "Não existe um usuário com ID '{id}'",
+	},
+	CodigoPrestamoNoEncontrado: {
+		"es": "No existe un préstamo activo para el libro '{id}'",
+		"en": "There is no active loan for book '{id}'",
+		"pt": "Não existe um empréstimo ativo para o livro '{id}'",
+	},
+	CodigoMultaNoEncontrada: {
+		"es": "No existe una multa con ID '{id}'",
+		"en": "There is no fine with ID '{id}'",
+		"pt": "Não existe uma multa com ID '{id}'",
+	},
+	CodigoUsuarioNoHabilitado: {
+		"es": "El usuario '{id}' no puede pedir préstamos",
+		"en": "User '{id}' is not allowed to borrow",
+		"pt": "O usuário '{id}' não pode pegar empréstimos",
+	},
+	CodigoLibroYaPrestado: {
+		"es": "El libro '{id}' ya está prestado",
+		"en": "Book '{id}' is already on loan",
+		"pt": "O livro '{id}' já está emprestado",
+	},
+	CodigoLibroNoPrestado: {
+		"es": "El libro '{id}' no está prestado",
+		"en": "Book '{id}' is not on loan",
+		"pt": "O livro '{id}' não está emprestado",
+	},
+	CodigoLibroNoDisponible: {
+		"es": "El libro '{id}' no está disponible",
+		"en": "Book '{id}' is not available",
+		"pt": "O livro '{id}' não está disponível",
+	},
+	CodigoOperacionInvalida: {
+		"es": "Operación no permitida sobre {campo} '{id}'",
+		"en": "Operation not allowed on {campo} '{id}'",
+		"pt": "Operação não permitida em {campo} '{id}'",
+	},
+	CodigoPrestamoDenegado: {
+		"es": "La política de préstamo no permite prestar el libro '{id}' al usuario '{valor}'",
+		"en": "Lending policy does not allow lending book '{id}' to user '{valor}'",
+		"pt": "A política de empréstimo não permite emprestar o livro '{id}' ao usuário '{valor}'",
+	},
+}
+
+// MensajeLocalizado retorna el mensaje del error en el idioma pedido
+// ("es", "en", "pt"); si no hay traducción usa el mensaje original
+func (e *ErrorBiblioteca) MensajeLocalizado(idioma Idioma) string {
+	plantilla, ok := mensajesError[e.Codigo][idioma]
+	if !ok {
+		return e.Error()
+	}
+	return strings.NewReplacer(
+		"{id}", strconv.Itoa(e.ID),
+		"{campo}", e.Campo,
+		"{valor}", e.Valor,
+	).Replace(plantilla)
+}
+
+// nuevoError construye un ErrorBiblioteca con un mensaje en formato fmt
+func nuevoError(codigo CodigoError, entidad string, id int, campo, valor, formato string, args ...any) error {
+	return &ErrorBiblioteca{
+		Codigo:  codigo,
+		Entidad: entidad,
+		ID:      id,
+		Campo:   campo,
+		Valor:   valor,
+		Mensaje: fmt.Sprintf(formato, args...),
+	}
+}
+
+func errLibroNoEncontrado(libroID int) error {
+	return nuevoError(CodigoLibroNoEncontrado, "libro", libroID, "id", strconv.Itoa(libroID),
+		"No existe un libro con ID '%d'", libroID)
+}
+
+func errUsuarioNoEncontrado(usuarioID int) error {
+	return nuevoError(CodigoUsuarioNoEncontrado, "usuario", usuarioID, "id", strconv.Itoa(usuarioID),
+		"No existe un usuario con ID '%d'", usuarioID)
+}
+
+func errDatoRequerido(entidad, campo, mensaje string) error {
+	return nuevoError(CodigoDatoRequerido, entidad, 0, campo, "", "%s", mensaje)
+}
+
+// CodigoDe extrae el código de cualquier error de la biblioteca,
+// aunque venga envuelto con %w; retorna "" si no es uno
+func CodigoDe(err error) CodigoError {
+	var e *ErrorBiblioteca
+	if errors.As(err, &e) {
+		return e.Codigo
+	}
+	return ""
+}
+
+// campoVacio retorna el primer campo si su valor está vacío o, si no, el segundo
+func campoVacio(primero, valor, segundo string) string {
+	if valor == "" {
+		return primero
+	}
+	return segundo
+}
@@ -0,0 +1,431 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ==========================================
+// ESCENARIOS: MINI LENGUAJE PARA PRUEBAS DE ACEPTACIÓN
+// ==========================================
+//
+// Cada línea es un comando; las que empiezan con # son comentarios.
+//
+//	fecha 2026-01-05
+//	agregar libro "El Quijote" "Miguel de Cervantes" "978-84-376-0494-7" 863 como quijote
+//	registrar usuario "Ana" "ana@gmail.com" "+51 999 999 999" como ana
+//	prestar quijote a ana en 2026-01-05
+//	prestar quijote a ana
+//	esperar error "ya está prestado"
+//	esperar 20d
+//	devolver quijote
+//	verificar multas ana 3.00
+//
+// Los libros y usuarios se nombran por su alias o por su ID.
+// Un comando que falla debe ir seguido de "esperar error"; si no, el
+// escenario se detiene y reporta la línea.
+
+// fechaInicioEscenario es la fecha del reloj falso si el guion no fija otra
+var fechaInicioEscenario = time.Date(2026, time.January, 5, 9, 0, 0, 0, time.UTC)
+
+// Escenario ejecuta guiones contra una biblioteca con reloj falso
+type Escenario struct {
+	Biblioteca *Biblioteca
+	Reloj      *RelojFalso
+	alias      map[string]int
+
+	errorPendiente error // error del último comando, aún sin verificar
+	lineaPendiente int
+}
+
+// NuevoEscenario crea una biblioteca vacía con el reloj en la fecha dada
+func NuevoEscenario(inicio time.Time) *Escenario {
+	reloj := NuevoRelojFalso(inicio)
+	biblioteca := NuevaBiblioteca("Biblioteca de Escenarios", "")
+	biblioteca.Reloj = reloj
+	return &Escenario{
+		Biblioteca: biblioteca,
+		Reloj:      reloj,
+		alias:      make(map[string]int),
+	}
+}
+
+// Ejecutar corre el guion línea por línea y se detiene en el primer fallo
+func (e *Escenario) Ejecutar(guion io.Reader) error {
+	scanner := bufio.NewScanner(guion)
+	numeroLinea := 0
+	for scanner.Scan() {
+		numeroLinea++
+		linea := strings.TrimSpace(scanner.Text())
+		if linea == "" || strings.HasPrefix(linea, "#") {
+			continue
+		}
+		tokens, err := dividirTokens(linea)
+		if err != nil {
+			return fmt.Errorf("línea %d: %w", numeroLinea, err)
+		}
+
+		if len(tokens) >= 2 && tokens[0] == "esperar" && tokens[1] == "error" {
+			if err := e.verificarError(tokens[2:]); err != nil {
+				return fmt.Errorf("línea %d: %w", numeroLinea, err)
+			}
+			continue
+		}
+		if e.errorPendiente != nil {
+			return fmt.Errorf("línea %d: error inesperado: %v", e.lineaPendiente, e.errorPendiente)
+		}
+
+		resultado, err := e.ejecutarComando(tokens)
+		if err != nil {
+			return fmt.Errorf("línea %d: %w", numeroLinea, err)
+		}
+		if resultado != nil {
+			e.errorPendiente = resultado
+			e.lineaPendiente = numeroLinea
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if e.errorPendiente != nil {
+		return fmt.Errorf("línea %d: error inesperado: %v", e.lineaPendiente, e.errorPendiente)
+	}
+	return nil
+}
+
+// EjecutarEscenarioArchivo corre un guion guardado en disco
+func EjecutarEscenarioArchivo(ruta string) error {
+	archivo, err := os.Open(ruta)
+	if err != nil {
+		return err
+	}
+	defer archivo.Close()
+	return NuevoEscenario(fechaInicioEscenario).Ejecutar(archivo)
+}
+
+// ejecutarComando retorna dos errores distintos: el primero es el
+// resultado de la operación de la biblioteca (puede ser esperado por el
+// guion) y el segundo indica un guion mal escrito o una verificación fallida
+func (e *Escenario) ejecutarComando(t []string) (errOperacion, errGuion error) {
+	switch {
+	case coincide(t, "fecha", "_"):
+		fecha, err := time.Parse(formatoFecha, t[1])
+		if err != nil {
+			return nil, fmt.Errorf("fecha no válida '%s'", t[1])
+		}
+		e.Reloj.Fijar(fecha.Add(9 * time.Hour))
+		return nil, nil
+
+	case coincide(t, "esperar", "_"):
+		duracion, err := parsearDuracion(t[1])
+		if err != nil {
+			return nil, err
+		}
+		e.Reloj.Avanzar(duracion)
+		return nil, nil
+
+	case coincide(t, "agregar", "libro", "_", "_", "_", "_"):
+		paginas, err := strconv.Atoi(t[5])
+		if err != nil {
+			return nil, fmt.Errorf("páginas no válidas '%s'", t[5])
+		}
+		libro, err := e.Biblioteca.AgregarLibro(t[2], t[3], t[4], paginas)
+		if err == nil {
+			return nil, e.registrarAlias(t[6:], libro.ID)
+		}
+		return err, nil
+
+	case coincide(t, "registrar", "usuario", "_", "_", "_"):
+		usuario, err := e.Biblioteca.RegistrarUsuario(t[2], t[3], t[4])
+		if err == nil {
+			return nil, e.registrarAlias(t[5:], usuario.ID)
+		}
+		return err, nil
+
+	case coincide(t, "prestar", "_", "a", "_"):
+		libroID, err := e.resolver(t[1])
+		if err != nil {
+			return nil, err
+		}
+		usuarioID, err := e.resolver(t[3])
+		if err != nil {
+			return nil, err
+		}
+		if err := e.moverRelojOpcional(t[4:]); err != nil {
+			return nil, err
+		}
+		return e.Biblioteca.PrestarLibro(libroID, usuarioID), nil
+
+	case coincide(t, "devolver", "_"):
+		libroID, err := e.resolver(t[1])
+		if err != nil {
+			return nil, err
+		}
+		if err := e.moverRelojOpcional(t[2:]); err != nil {
+			return nil, err
+		}
+		return e.Biblioteca.DevolverLibro(libroID), nil
+
+	case coincide(t, "renovar", "_"), coincide(t, "reparar", "_"), coincide(t, "reincorporar", "_"):
+		libroID, err := e.resolver(t[1])
+		if err != nil {
+			return nil, err
+		}
+		switch t[0] {
+		case "renovar":
+			return e.Biblioteca.RenovarPrestamo(libroID), nil
+		case "reparar":
+			return e.Biblioteca.EnviarLibroAReparacion(libroID), nil
+		}
+		return e.Biblioteca.ReincorporarLibro(libroID), nil
+
+	case coincide(t, "dar", "de", "baja", "_"):
+		libroID, err := e.resolver(t[3])
+		if err != nil {
+			return nil, err
+		}
+		return e.Biblioteca.DarDeBajaLibro(libroID), nil
+
+	case coincide(t, "perder", "_"):
+		libroID, err := e.resolver(t[1])
+		if err != nil {
+			return nil, err
+		}
+		costo := 0.0
+		if coincide(t[2:], "costo", "_") {
+			if costo, err = strconv.ParseFloat(t[3], 64); err != nil {
+				return nil, fmt.Errorf("costo no válido '%s'", t[3])
+			}
+		}
+		_, err = e.Biblioteca.ReportarPerdida(libroID, costo)
+		return err, nil
+
+	case coincide(t, "pagar", "multas", "_"):
+		usuarioID, err := e.resolver(t[2])
+		if err != nil {
+			return nil, err
+		}
+		for _, multa := range e.Biblioteca.MultasPendientes(usuarioID) {
+			if err := e.Biblioteca.PagarMulta(multa.ID); err != nil {
+				return err, nil
+			}
+		}
+		return nil, nil
+
+	case coincide(t, "desactivar", "_"), coincide(t, "activar", "_"):
+		usuarioID, err := e.resolver(t[1])
+		if err != nil {
+			return nil, err
+		}
+		usuario := e.Biblioteca.BuscarUsuario(usuarioID)
+		if usuario == nil {
+			return errUsuarioNoEncontrado(usuarioID), nil
+		}
+		if t[0] == "activar" {
+			usuario.Activar()
+		} else {
+			usuario.Desactivar()
+		}
+		return nil, nil
+
+	case coincide(t, "verificar", "libro", "_", "_"):
+		return nil, e.verificarLibro(t[2], strings.Join(t[3:], " "))
+
+	case coincide(t, "verificar", "multas", "_", "_"):
+		esperado, err := strconv.ParseFloat(t[3], 64)
+		if err != nil {
+			return nil, fmt.Errorf("monto no válido '%s'", t[3])
+		}
+		usuarioID, err := e.resolver(t[2])
+		if err != nil {
+			return nil, err
+		}
+		total := 0.0
+		for _, multa := range e.Biblioteca.MultasPendientes(usuarioID) {
+			total += multa.Monto
+		}
+		if math.Abs(total-esperado) > 0.005 {
+			return nil, fmt.Errorf("se esperaban multas por %.2f y hay %.2f", esperado, total)
+		}
+		return nil, nil
+
+	case coincide(t, "verificar", "prestamos", "activos", "_"):
+		esperado, err := strconv.Atoi(t[3])
+		if err != nil {
+			return nil, fmt.Errorf("cantidad no válida '%s'", t[3])
+		}
+		activos := 0
+		for _, prestamo := range e.Biblioteca.Prestamos {
+			if !prestamo.Devuelto {
+				activos++
+			}
+		}
+		if activos != esperado {
+			return nil, fmt.Errorf("se esperaban %d préstamos activos y hay %d", esperado, activos)
+		}
+		return nil, nil
+	}
+	return nil, fmt.Errorf("comando no reconocido: %s", strings.Join(t, " "))
+}
+
+// verificarError compara el error pendiente con el texto o código esperado
+func (e *Escenario) verificarError(args []string) error {
+	errorActual := e.errorPendiente
+	e.errorPendiente = nil
+	if errorActual == nil {
+		return fmt.Errorf("se esperaba un error y el comando anterior funcionó")
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	esperado := strings.Join(args, " ")
+	if string(CodigoDe(errorActual)) == esperado ||
+		strings.Contains(strings.ToLower(errorActual.Error()), strings.ToLower(esperado)) {
+		return nil
+	}
+	return fmt.Errorf("se esperaba el error %q y se obtuvo %q", esperado, errorActual.Error())
+}
+
+func (e *Escenario) verificarLibro(referencia, esperado string) error {
+	libroID, err := e.resolver(referencia)
+	if err != nil {
+		return err
+	}
+	libro := e.Biblioteca.BuscarLibro(libroID)
+	if libro == nil {
+		return fmt.Errorf("no existe el libro '%s'", referencia)
+	}
+	actual := string(libro.Estado)
+	if libro.Prestado {
+		actual = "prestado"
+	} else if libro.Estado == EnCirculacion {
+		actual = "disponible"
+	}
+	if actual != esperado {
+		return fmt.Errorf("el libro '%s' está %s, se esperaba %s", libro.Titulo, actual, esperado)
+	}
+	return nil
+}
+
+// registrarAlias guarda "como <alias>" si el comando lo incluye
+func (e *Escenario) registrarAlias(resto []string, id int) error {
+	if len(resto) == 0 {
+		return nil
+	}
+	if !coincide(resto, "como", "_") || len(resto) != 2 {
+		return fmt.Errorf("se esperaba 'como <alias>' y se encontró '%s'", strings.Join(resto, " "))
+	}
+	e.alias[resto[1]] = id
+	return nil
+}
+
+// moverRelojOpcional procesa el sufijo "en <fecha>" de un comando
+func (e *Escenario) moverRelojOpcional(resto []string) error {
+	if len(resto) == 0 {
+		return nil
+	}
+	if !coincide(resto, "en", "_") || len(resto) != 2 {
+		return fmt.Errorf("se esperaba 'en <fecha>' y se encontró '%s'", strings.Join(resto, " "))
+	}
+	fecha, err := time.Parse(formatoFecha, resto[1])
+	if err != nil {
+		return fmt.Errorf("fecha no válida '%s'", resto[1])
+	}
+	e.Reloj.Fijar(fecha.Add(9 * time.Hour))
+	return nil
+}
+
+// resolver traduce un alias o un ID numérico. Un alias desconocido es un
+// error del guion: casi siempre es una errata y no debe pasar como ID 0.
+func (e *Escenario) resolver(referencia string) (int, error) {
+	if id, ok := e.alias[referencia]; ok {
+		return id, nil
+	}
+	id, err := strconv.Atoi(referencia)
+	if err != nil {
+		return 0, fmt.Errorf("alias desconocido '%s'", referencia)
+	}
+	return id, nil
+}
+
+// coincide verifica que los tokens empiecen con el patrón; "_" acepta cualquier valor
+func coincide(tokens []string, patron ...string) bool {
+	if len(tokens) < len(patron) {
+		return false
+	}
+	for i, p := range patron {
+		if p != "_" && tokens[i] != p {
+			return false
+		}
+	}
+	return true
+}
+
+// parsearDuracion acepta "20d" además de las duraciones de Go ("3h", "30m")
+func parsearDuracion(texto string) (time.Duration, error) {
+	if dias, ok := strings.CutSuffix(texto, "d"); ok {
+		n, err := strconv.Atoi(dias)
+		if err != nil {
+			return 0, fmt.Errorf("duración no válida '%s'", texto)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	duracion, err := time.ParseDuration(texto)
+	if err != nil {
+		return 0, fmt.Errorf("duración no válida '%s'", texto)
+	}
+	return duracion, nil
+}
+
+// dividirTokens separa por espacios respetando los textos entre comillas
+func dividirTokens(linea string) ([]string, error) {
+	tokens := make([]string, 0)
+	var actual strings.Builder
+	enComillas, hayToken := false, false
+	for _, r := range linea {
+		switch {
+		case r == '"':
+			enComillas = !enComillas
+			hayToken = true
+		case (r == ' ' || r == '\t') && !enComillas:
+			if hayToken {
+				tokens = append(tokens, actual.String())
+				actual.Reset()
+				hayToken = false
+			}
+		default:
+			actual.WriteRune(r)
+			hayToken = true
+		}
+	}
+	if enComillas {
+		return nil, fmt.Errorf("comillas sin cerrar")
+	}
+	if hayToken {
+		tokens = append(tokens, actual.String())
+	}
+	return tokens, nil
+}
+
+// ejecutarEscenariosCLI corre los guiones indicados y retorna el código de salida
+func ejecutarEscenariosCLI(rutas []string) int {
+	fallidos := 0
+	for _, ruta := range rutas {
+		if err := EjecutarEscenarioArchivo(ruta); err != nil {
+			fmt.Printf("❌ %s: %v\n", ruta, err)
+			fallidos++
+			continue
+		}
+		fmt.Printf("✅ %s\n", ruta)
+	}
+	if fallidos > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEscenarios(t *testing.T) {
+	rutas, err := filepath.Glob(filepath.Join("escenarios", "*.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rutas) == 0 {
+		t.Fatal("no hay escenarios en escenarios/")
+	}
+	for _, ruta := range rutas {
+		t.Run(filepath.Base(ruta), func(t *testing.T) {
+			if err := EjecutarEscenarioArchivo(ruta); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestEscenarioAliasDesconocido(t *testing.T) {
+	guion := `agregar libro "El Quijote" "Miguel de Cervantes" "978-84-376-0494-7" 863 como quijote
+registrar usuario "Ana" "ana@gmail.com" "987 654 321" como ana
+prestar quijote a anna`
+	err := NuevoEscenario(fechaInicioEscenario).Ejecutar(strings.NewReader(guion))
+	if err == nil || !strings.Contains(err.Error(), "alias desconocido 'anna'") {
+		t.Fatalf("se esperaba un error por el alias 'anna', se obtuvo %v", err)
+	}
+}
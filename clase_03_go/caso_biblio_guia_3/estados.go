@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ==========================================
+// ESTADOS DEL LIBRO: REPARACIÓN, PÉRDIDA Y BAJA
+// ==========================================
+
+// EstadoLibro indica si un libro está en circulación o fuera de ella
+type EstadoLibro string
+
+const (
+	EnCirculacion EstadoLibro = "en circulación"
+	EnReparacion  EstadoLibro = "en reparación"
+	Perdido       EstadoLibro = "perdido"
+	DadoDeBaja    EstadoLibro = "dado de baja"
+)
+
+// Multa representa un cobro pendiente o pagado de un usuario
+type Multa struct {
+	ID         int
+	UsuarioID  int
+	PrestamoID int
+	Motivo     string
+	Monto      float64
+	Fecha      time.Time
+	Pagada     bool
+}
+
+// EnviarAReparacion retira temporalmente el libro de circulación
+// Usa receptor de PUNTERO porque MODIFICA el estado
+func (l *Libro) EnviarAReparacion() error {
+	if l.Prestado {
+		return nuevoError(CodigoLibroYaPrestado, "libro", l.ID, "prestado", "true",
+			"El libro '%s' está prestado", l.Titulo)
+	}
+	if l.Estado != EnCirculacion {
+		return nuevoError(CodigoLibroNoDisponible, "libro", l.ID, "estado", string(l.Estado),
+			"El libro '%s' está %s", l.Titulo, l.Estado)
+	}
+	l.Estado = EnReparacion
+	return nil
+}
+
+// Reincorporar devuelve a circulación un libro reparado o encontrado
+func (l *Libro) Reincorporar() error {
+	if l.Estado != EnReparacion && l.Estado != Perdido {
+		return nuevoError(CodigoOperacionInvalida, "libro", l.ID, "estado", string(l.Estado),
+			"El libro '%s' no está en reparación ni perdido", l.Titulo)
+	}
+	l.Estado = EnCirculacion
+	return nil
+}
+
+// DarDeBaja retira definitivamente el libro; se conserva por historial
+func (l *Libro) DarDeBaja() error {
+	if l.Prestado {
+		return nuevoError(CodigoLibroYaPrestado, "libro", l.ID, "prestado", "true",
+			"El libro '%s' está prestado", l.Titulo)
+	}
+	if l.Estado == DadoDeBaja {
+		return nuevoError(CodigoOperacionInvalida, "libro", l.ID, "estado", string(l.Estado),
+			"El libro '%s' ya está dado de baja", l.Titulo)
+	}
+	l.Estado = DadoDeBaja
+	return nil
+}
+
+// EnviarLibroAReparacion retira un libro de circulación para repararlo
+func (b *Biblioteca) EnviarLibroAReparacion(libroID int) error {
+	libro := b.BuscarLibro(libroID)
+	if libro == nil {
+		return errLibroNoEncontrado(libroID)
+	}
+	return libro.EnviarAReparacion()
+}
+
+// ReincorporarLibro vuelve a poner en circulación un libro
+func (b *Biblioteca) ReincorporarLibro(libroID int) error {
+	libro := b.BuscarLibro(libroID)
+	if libro == nil {
+		return errLibroNoEncontrado(libroID)
+	}
+	return libro.Reincorporar()
+}
+
+// DarDeBajaLibro retira un libro del catálogo sin borrar su historial
+func (b *Biblioteca) DarDeBajaLibro(libroID int) error {
+	libro := b.BuscarLibro(libroID)
+	if libro == nil {
+		return errLibroNoEncontrado(libroID)
+	}
+	if err := libro.DarDeBaja(); err != nil {
+		return err
+	}
+	b.publicar(Evento{Tipo: EventoLibroDadoDeBaja, LibroID: libroID})
+	return nil
+}
+
+// ReportarPerdida cierra el préstamo activo de un libro perdido y
+// cobra al usuario el costo de reposición
+func (b *Biblioteca) ReportarPerdida(libroID int, costoReposicion float64) (*Multa, error) {
+	libro := b.BuscarLibro(libroID)
+	if libro == nil {
+		return nil, errLibroNoEncontrado(libroID)
+	}
+	if costoReposicion < 0 {
+		return nil, nuevoError(CodigoDatoInvalido, "multa", 0, "monto", strconv.FormatFloat(costoReposicion, 'f', 2, 64),
+			"El costo de reposición no puede ser negativo")
+	}
+
+	prestamoActivo := b.buscarPrestamoActivo(libroID)
+	if prestamoActivo == nil {
+		return nil, nuevoError(CodigoPrestamoNoEncontrado, "libro", libro.ID, "", "",
+			"No existe un prestamo activo para el libro '%s'", libro.Titulo)
+	}
+
+	libro.Prestado = false
+	libro.Estado = Perdido
+	prestamoActivo.Devuelto = true
+	prestamoActivo.Perdido = true
+
+	multa := Multa{
+		ID:         b.proximoID,
+		UsuarioID:  prestamoActivo.UsuarioID,
+		PrestamoID: prestamoActivo.ID,
+		Motivo:     fmt.Sprintf("Reposición del libro '%s'", libro.Titulo),
+		Monto:      costoReposicion,
+		Fecha:      b.ahora(),
+	}
+	b.Multas = append(b.Multas, multa)
+	b.proximoID++
+	b.publicar(Evento{Tipo: EventoLibroPerdido, LibroID: libroID,
+		UsuarioID: multa.UsuarioID, PrestamoID: multa.PrestamoID, MultaID: multa.ID})
+
+	return &b.Multas[len(b.Multas)-1], nil
+}
+
+// PagarMulta marca una multa como pagada
+func (b *Biblioteca) PagarMulta(multaID int) error {
+	for i := range b.Multas {
+		if b.Multas[i].ID == multaID {
+			if b.Multas[i].Pagada {
+				return nuevoError(CodigoOperacionInvalida, "multa", multaID, "pagada", "true",
+					"La multa '%d' ya está pagada", multaID)
+			}
+			b.Multas[i].Pagada = true
+			return nil
+		}
+	}
+	return nuevoError(CodigoMultaNoEncontrada, "multa", multaID, "id", strconv.Itoa(multaID),
+		"No existe una multa con ID '%d'", multaID)
+}
+
+// MultasPendientes retorna las multas sin pagar de un usuario
+func (b Biblioteca) MultasPendientes(usuarioID int) []Multa {
+	pendientes := make([]Multa, 0)
+	for _, multa := range b.Multas {
+		if multa.UsuarioID == usuarioID && !multa.Pagada {
+			pendientes = append(pendientes, multa)
+		}
+	}
+	return pendientes
+}
+
+// BuscarLibros busca por título o autor, sin incluir libros dados de baja
+func (b Biblioteca) BuscarLibros(consulta string) []*Libro {
+	consulta = strings.ToLower(strings.TrimSpace(consulta))
+	resultados := make([]*Libro, 0)
+	for i, libro := range b.Libros {
+		if libro.Estado == DadoDeBaja {
+			continue
+		}
+		if strings.Contains(strings.ToLower(libro.Titulo), consulta) ||
+			strings.Contains(strings.ToLower(libro.Autor), consulta) {
+			resultados = append(resultados, &b.Libros[i])
+		}
+	}
+	return resultados
+}
+
+// buscarPrestamoActivo retorna el préstamo sin cerrar de un libro
+func (b *Biblioteca) buscarPrestamoActivo(libroID int) *Prestamo {
+	for i := range b.Prestamos {
+		if b.Prestamos[i].LibroID == libroID && !b.Prestamos[i].Devuelto {
+			return &b.Prestamos[i]
+		}
+	}
+	return nil
+}
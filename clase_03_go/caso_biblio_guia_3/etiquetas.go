@@ -0,0 +1,235 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"biblio/codigobarras"
+)
+
+// ==========================================
+// ETIQUETAS, CÓDIGOS DE BARRAS Y CARNETS
+// ==========================================
+
+// Hoja A4 en milímetros, con márgenes y una grilla de etiquetas
+const (
+	hojaAncho  = 210.0
+	hojaAlto   = 297.0
+	hojaMargen = 10.0
+)
+
+// Etiqueta es un elemento imprimible de tamaño fijo (en milímetros)
+type Etiqueta struct {
+	Ancho     float64
+	Alto      float64
+	Contenido string // elementos SVG relativos a la esquina superior izquierda
+}
+
+// CodigoBarras retorna el EAN-13 del ISBN o, si el libro no tiene un
+// ISBN válido, un Code 128 con su ID
+func (l Libro) CodigoBarras() (codigobarras.Codigo, error) {
+	if l.ISBN != "" {
+		if ean, err := codigobarras.ISBNaEAN13(l.ISBN); err == nil {
+			return codigobarras.EAN13(ean)
+		}
+	}
+	return codigobarras.Code128(fmt.Sprintf("L%06d", l.ID))
+}
+
+// CodigoBarras retorna el Code 128 con el ID del usuario para su carnet
+func (u Usuario) CodigoBarras() (codigobarras.Codigo, error) {
+	return codigobarras.Code128(fmt.Sprintf("%08d", u.ID))
+}
+
+// Signatura retorna la signatura topográfica del libro: tres letras
+// del apellido del autor, tres del título y el ID
+func (l Libro) Signatura() string {
+	palabras := strings.Fields(l.Autor)
+	apellido := ""
+	if len(palabras) > 0 {
+		apellido = palabras[len(palabras)-1]
+	}
+	return fmt.Sprintf("%s %s %d", prefijoSignatura(apellido, true), prefijoSignatura(l.Titulo, false), l.ID)
+}
+
+// EtiquetaLomo arma la etiqueta que se pega en el lomo del libro
+func (l Libro) EtiquetaLomo() Etiqueta {
+	var sb strings.Builder
+	lineas := strings.Fields(l.Signatura())
+	for i, linea := range lineas {
+		fmt.Fprintf(&sb, `<text x="12.5" y="%.1f" font-family="sans-serif" font-size="5" font-weight="bold" text-anchor="middle">%s</text>`,
+			9+float64(i)*7, codigobarras.EscaparXML(linea))
+	}
+	return Etiqueta{Ancho: 25, Alto: 35, Contenido: sb.String()}
+}
+
+// EtiquetaCodigo arma la etiqueta con el código de barras del libro
+func (l Libro) EtiquetaCodigo() (Etiqueta, error) {
+	codigo, err := l.CodigoBarras()
+	if err != nil {
+		return Etiqueta{}, err
+	}
+	const ancho = 63.5
+	anchoModulo := (ancho - 4) / float64(codigo.Ancho())
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<text x="2" y="5" font-family="sans-serif" font-size="3.2">%s</text>`,
+		codigobarras.EscaparXML(recortar(l.Titulo, 34)))
+	fmt.Fprintf(&sb, `<g fill="black">%s</g>`, codigo.Rects(2, 7, anchoModulo, 16))
+	fmt.Fprintf(&sb, `<text x="%.2f" y="27" font-family="monospace" font-size="3" text-anchor="middle">%s</text>`,
+		ancho/2, codigo.Texto)
+	fmt.Fprintf(&sb, `<text x="%.2f" y="32" font-family="sans-serif" font-size="3" text-anchor="end">%s</text>`,
+		ancho-2, codigobarras.EscaparXML(l.Signatura()))
+	return Etiqueta{Ancho: ancho, Alto: 35, Contenido: sb.String()}, nil
+}
+
+// Carnet arma el carnet de biblioteca del usuario (tamaño tarjeta)
+func (u Usuario) Carnet(nombreBiblioteca string) (Etiqueta, error) {
+	codigo, err := u.CodigoBarras()
+	if err != nil {
+		return Etiqueta{}, err
+	}
+	const ancho, alto = 85.6, 54.0
+	anchoModulo := (ancho - 10) / float64(codigo.Ancho())
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<rect x="0.5" y="0.5" width="%.1f" height="%.1f" rx="3" fill="none" stroke="black" stroke-width="0.3"/>`,
+		ancho-1, alto-1)
+	fmt.Fprintf(&sb, `<text x="5" y="9" font-family="sans-serif" font-size="4.5" font-weight="bold">%s</text>`,
+		codigobarras.EscaparXML(nombreBiblioteca))
+	fmt.Fprintf(&sb, `<text x="5" y="18" font-family="sans-serif" font-size="4">%s</text>`,
+		codigobarras.EscaparXML(u.Nombre))
+	fmt.Fprintf(&sb, `<text x="5" y="23" font-family="sans-serif" font-size="3">%s</text>`,
+		codigobarras.EscaparXML(u.Email))
+	fmt.Fprintf(&sb, `<g fill="black">%s</g>`, codigo.Rects(5, 28, anchoModulo, 14))
+	fmt.Fprintf(&sb, `<text x="%.1f" y="47" font-family="monospace" font-size="3.5" text-anchor="middle">%s</text>`,
+		ancho/2, codigo.Texto)
+	return Etiqueta{Ancho: ancho, Alto: alto, Contenido: sb.String()}, nil
+}
+
+// HojaSVG distribuye las etiquetas en hojas A4 y retorna un SVG por hoja
+func HojaSVG(etiquetas []Etiqueta) []string {
+	hojas := make([]string, 0)
+	var sb strings.Builder
+	x, y, altoFila := hojaMargen, hojaMargen, 0.0
+
+	abrirHoja := func() {
+		sb.Reset()
+		fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0fmm" height="%.0fmm" viewBox="0 0 %.0f %.0f">`,
+			hojaAncho, hojaAlto, hojaAncho, hojaAlto)
+		sb.WriteString(`<rect width="100%" height="100%" fill="white"/>`)
+	}
+	cerrarHoja := func() {
+		sb.WriteString("</svg>")
+		hojas = append(hojas, sb.String())
+	}
+
+	abrirHoja()
+	for i, etiqueta := range etiquetas {
+		if x+etiqueta.Ancho > hojaAncho-hojaMargen {
+			x, y, altoFila = hojaMargen, y+altoFila+2, 0
+		}
+		if y+etiqueta.Alto > hojaAlto-hojaMargen {
+			cerrarHoja()
+			abrirHoja()
+			x, y, altoFila = hojaMargen, hojaMargen, 0
+		}
+		fmt.Fprintf(&sb, `<g id="etiqueta-%d" transform="translate(%.2f %.2f)">`, i+1, x, y)
+		fmt.Fprintf(&sb, `<rect width="%.2f" height="%.2f" fill="none" stroke="#ccc" stroke-width="0.2" stroke-dasharray="1 1"/>`,
+			etiqueta.Ancho, etiqueta.Alto)
+		sb.WriteString(etiqueta.Contenido)
+		sb.WriteString("</g>")
+		x += etiqueta.Ancho + 2
+		if etiqueta.Alto > altoFila {
+			altoFila = etiqueta.Alto
+		}
+	}
+	cerrarHoja()
+	return hojas
+}
+
+// HojasEtiquetasLibros genera etiquetas de código y de lomo para los
+// libros indicados, o para todo el catálogo si no se indica ninguno
+func (b Biblioteca) HojasEtiquetasLibros(ids ...int) ([]string, error) {
+	libros, err := b.seleccionarLibros(ids)
+	if err != nil {
+		return nil, err
+	}
+	etiquetas := make([]Etiqueta, 0, 2*len(libros))
+	for _, libro := range libros {
+		codigo, err := libro.EtiquetaCodigo()
+		if err != nil {
+			return nil, fmt.Errorf("libro '%d': %w", libro.ID, err)
+		}
+		etiquetas = append(etiquetas, codigo, libro.EtiquetaLomo())
+	}
+	return HojaSVG(etiquetas), nil
+}
+
+// HojasCarnets genera los carnets de los usuarios activos
+func (b Biblioteca) HojasCarnets() ([]string, error) {
+	carnets := make([]Etiqueta, 0, len(b.Usuarios))
+	for _, usuario := range b.Usuarios {
+		if !usuario.Activo {
+			continue
+		}
+		carnet, err := usuario.Carnet(b.Nombre)
+		if err != nil {
+			return nil, fmt.Errorf("usuario '%d': %w", usuario.ID, err)
+		}
+		carnets = append(carnets, carnet)
+	}
+	return HojaSVG(carnets), nil
+}
+
+func (b Biblioteca) seleccionarLibros(ids []int) ([]Libro, error) {
+	if len(ids) == 0 {
+		libros := make([]Libro, 0, len(b.Libros))
+		for _, libro := range b.Libros {
+			if libro.Estado != DadoDeBaja {
+				libros = append(libros, libro)
+			}
+		}
+		return libros, nil
+	}
+	libros := make([]Libro, 0, len(ids))
+	for _, id := range ids {
+		libro := b.BuscarLibro(id)
+		if libro == nil {
+			return nil, errLibroNoEncontrado(id)
+		}
+		libros = append(libros, *libro)
+	}
+	return libros, nil
+}
+
+// prefijoSignatura toma las tres primeras letras de un texto
+func prefijoSignatura(texto string, mayusculas bool) string {
+	letras := make([]rune, 0, 3)
+	for _, r := range texto {
+		if unicode.IsLetter(r) {
+			letras = append(letras, unicode.ToLower(r))
+		}
+		if len(letras) == 3 {
+			break
+		}
+	}
+	if len(letras) == 0 {
+		return "X"
+	}
+	if mayusculas {
+		return strings.ToUpper(string(letras))
+	}
+	letras[0] = unicode.ToUpper(letras[0])
+	return string(letras)
+}
+
+func recortar(texto string, maximo int) string {
+	runas := []rune(texto)
+	switch {
+	case len(runas) <= maximo:
+		return texto
+	case maximo <= 0:
+		return ""
+	}
+	return string(runas[:maximo-1]) + "…"
+}
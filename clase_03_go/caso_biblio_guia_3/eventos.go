@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// ==========================================
+// EVENTOS DE DOMINIO
+// ==========================================
+
+// TipoEvento identifica lo que ocurrió en la biblioteca
+type TipoEvento string
+
+const (
+	EventoLibroPrestado     TipoEvento = "libro.prestado"
+	EventoLibroDevuelto     TipoEvento = "libro.devuelto"
+	EventoLibroRenovado     TipoEvento = "libro.renovado"
+	EventoLibroPerdido      TipoEvento = "libro.perdido"
+	EventoMultaGenerada     TipoEvento = "multa.generada"
+	EventoUsuarioRegistrado TipoEvento = "usuario.registrado"
+	EventoLibroAgregado     TipoEvento = "libro.agregado"
+	EventoLibroDadoDeBaja   TipoEvento = "libro.dado_de_baja"
+)
+
+// Evento describe un cambio ocurrido en la biblioteca
+type Evento struct {
+	ID         string     `json:"id"`
+	Tipo       TipoEvento `json:"tipo"`
+	Fecha      time.Time  `json:"fecha"`
+	LibroID    int        `json:"libro_id,omitempty"`
+	UsuarioID  int        `json:"usuario_id,omitempty"`
+	PrestamoID int        `json:"prestamo_id,omitempty"`
+	MultaID    int        `json:"multa_id,omitempty"`
+}
+
+// ManejadorEventos recibe cada evento publicado
+type ManejadorEventos func(Evento)
+
+// Suscribir registra una función que se llama con cada evento.
+// Los manejadores se llaman en el mismo goroutine de la operación, así
+// que deben ser rápidos o delegar el trabajo lento a otro goroutine.
+func (b *Biblioteca) Suscribir(manejador ManejadorEventos) {
+	b.suscriptores = append(b.suscriptores, manejador)
+}
+
+// publicar completa el ID y la fecha del evento y lo entrega a los suscriptores
+func (b *Biblioteca) publicar(evento Evento) {
+	if len(b.suscriptores) == 0 {
+		return
+	}
+	b.secuenciaEventos++
+	evento.Fecha = b.ahora()
+	evento.ID = fmt.Sprintf("evt_%d_%d", evento.Fecha.UnixNano(), b.secuenciaEventos)
+	for _, manejador := range b.suscriptores {
+		manejador(evento)
+	}
+}
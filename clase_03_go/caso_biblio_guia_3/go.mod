@@ -1,3 +1,21 @@
 module biblio
 
 go 1.24.4
+
+require (
+	FyS_proyect v0.0.0
+	golang.org/x/term v0.40.0
+	google.golang.org/grpc v1.80.0
+	google.golang.org/protobuf v1.36.11
+)
+
+require (
+	golang.org/x/net v0.50.0 // indirect
+	golang.org/x/sys v0.41.0 // indirect
+	golang.org/x/text v0.34.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260120221211-b8f7ae30c516 // indirect
+)
+
+// El paquete telefono vive en el módulo del curso, un directorio arriba.
+// Este replace exige tener ese árbol al lado; sin él biblio no compila.
+replace FyS_proyect => ../
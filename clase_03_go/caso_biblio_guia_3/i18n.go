@@ -0,0 +1,259 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ==========================================
+// IDIOMAS: CATÁLOGO DE MENSAJES Y FORMATOS
+// ==========================================
+
+// Idioma es un código de idioma ISO 639-1
+type Idioma string
+
+const (
+	Espanol   Idioma = "es"
+	Ingles    Idioma = "en"
+	Portugues Idioma = "pt"
+)
+
+// catalogo contiene los textos visibles para cada idioma. Los textos no
+// llevan emojis: los iconos se agregan aparte salvo en modo plano.
+var catalogo = map[Idioma]map[string]string{
+	Espanol: {
+		"estadisticas.titulo":       "Estadísticas de %s:",
+		"estadisticas.total":        "Total de libros: %d",
+		"estadisticas.prestados":    "Libros prestados: %d",
+		"estadisticas.disponibles":  "Libros disponibles: %d",
+		"estadisticas.no_prestable": "En reparación o perdidos: %d",
+		"estadisticas.usuarios":     "Usuarios activos: %d",
+		"estadisticas.prestamos":    "Préstamos activos: %d",
+		"listado.titulo":            "Libros disponibles:",
+		"listado.vacio":             "No hay libros disponibles",
+		"listado.extenso":           "Libro extenso (%d %s)",
+		"libro.info":                "[%d] %s por %s - %s",
+		"estado.disponible":         "Disponible",
+		"estado.prestado":           "Prestado",
+		"estado.en circulación":     "En circulación",
+		"estado.en reparación":      "En reparación",
+		"estado.perdido":            "Perdido",
+		"estado.dado de baja":       "Dado de baja",
+		"aviso.vencimiento":         "Hola %s, recuerda devolver '%s' a más tardar el %s",
+		"inventario.titulo":         "Inventario (%d %s)",
+		"inventario.faltantes":      "Faltantes: %d",
+		"inventario.inesperados":    "Inesperados: %d",
+		"inventario.mal_ubicados":   "Mal ubicados: %d",
+		"inventario.prestados":      "Prestados en estante: %d",
+		"inventario.item":           "[%d] %s - esperado: %s, encontrado: %s",
+		"inventario.no catalogado":  "no catalogado",
+		"inventario.no escaneado":   "no escaneado",
+	},
+	Ingles: {
+		"estadisticas.titulo":       "Statistics for %s:",
+		"estadisticas.total":        "Total books: %d",
+		"estadisticas.prestados":    "Books on loan: %d",
+		"estadisticas.disponibles":  "Available books: %d",
+		"estadisticas.no_prestable": "In repair or lost: %d",
+		"estadisticas.usuarios":     "Active users: %d",
+		"estadisticas.prestamos":    "Active loans: %d",
+		"listado.titulo":            "Available books:",
+		"listado.vacio":             "No books available",
+		"listado.extenso":           "Long book (%d %s)",
+		"libro.info":                "[%d] %s by %s - %s",
+		"estado.disponible":         "Available",
+		"estado.prestado":           "On loan",
+		"estado.en circulación":     "In circulation",
+		"estado.en reparación":      "In repair",
+		"estado.perdido":            "Lost",
+		"estado.dado de baja":       "Withdrawn",
+		"aviso.vencimiento":         "Hi %s, please return '%s' by %s",
+		"inventario.titulo":         "Inventory (%d %s)",
+		"inventario.faltantes":      "Missing: %d",
+		"inventario.inesperados":    "Unexpected: %d",
+		"inventario.mal_ubicados":   "Misplaced: %d",
+		"inventario.prestados":      "On loan but shelved: %d",
+		"inventario.item":           "[%d] %s - expected: %s, found: %s",
+		"inventario.no catalogado":  "not catalogued",
+		"inventario.no escaneado":   "not scanned",
+	},
+	Portugues: {
+		"estadisticas.titulo":       "Estatísticas de %s:",
+		"estadisticas.total":        "Total de livros: %d",
+		"estadisticas.prestados":    "Livros emprestados: %d",
+		"estadisticas.disponibles":  "Livros disponíveis: %d",
+		"estadisticas.no_prestable": "Em reparo ou perdidos: %d",
+		"estadisticas.usuarios":     "Usuários ativos: %d",
+		"estadisticas.prestamos":    "Empréstimos ativos: %d",
+		"listado.titulo":            "Livros disponíveis:",
+		"listado.vacio":             "Não há livros disponíveis",
+		"listado.extenso":           "Livro extenso (%d %s)",
+		"libro.info":                "[%d] %s de %s - %s",
+		"estado.disponible":         "Disponível",
+		"estado.prestado":           "Emprestado",
+		"estado.en circulación":     "Em circulação",
+		"estado.en reparación":      "Em reparo",
+		"estado.perdido":            "Perdido",
+		"estado.dado de baja":       "Baixado",
+		"aviso.vencimiento":         "Olá %s, lembre-se de devolver '%s' até %s",
+		"inventario.titulo":         "Inventário (%d %s)",
+		"inventario.faltantes":      "Faltantes: %d",
+		"inventario.inesperados":    "Inesperados: %d",
+		"inventario.mal_ubicados":   "Mal localizados: %d",
+		"inventario.prestados":      "Emprestados na estante: %d",
+		"inventario.item":           "[%d] %s - esperado: %s, encontrado: %s",
+		"inventario.no catalogado":  "não catalogado",
+		"inventario.no escaneado":   "não escaneado",
+	},
+}
+
+// iconos decora los mensajes en la salida normal
+var iconos = map[string]string{
+	"estadisticas.titulo":       "📊",
+	"estadisticas.total":        "📚",
+	"estadisticas.prestados":    "📖",
+	"estadisticas.disponibles":  "📕",
+	"estadisticas.no_prestable": "🔧",
+	"estadisticas.usuarios":     "👥",
+	"estadisticas.prestamos":    "📋",
+	"listado.titulo":            "📚",
+	"listado.extenso":           "📖",
+	"inventario.titulo":         "📦",
+	"inventario.faltantes":      "❓",
+	"inventario.inesperados":    "⚠️",
+	"inventario.mal_ubicados":   "🔀",
+	"inventario.prestados":      "📋",
+}
+
+// plurales contiene la forma singular y plural de cada palabra contable
+var plurales = map[Idioma]map[string][2]string{
+	Espanol:   {"pagina": {"página", "páginas"}, "libro": {"libro", "libros"}, "dia": {"día", "días"}, "lectura": {"lectura", "lecturas"}},
+	Ingles:    {"pagina": {"page", "pages"}, "libro": {"book", "books"}, "dia": {"day", "days"}, "lectura": {"scan", "scans"}},
+	Portugues: {"pagina": {"página", "páginas"}, "libro": {"livro", "livros"}, "dia": {"dia", "dias"}, "lectura": {"leitura", "leituras"}},
+}
+
+var meses = map[Idioma][12]string{
+	Espanol: {"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio",
+		"agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+	Ingles: {"January", "February", "March", "April", "May", "June", "July",
+		"August", "September", "October", "November", "December"},
+	Portugues: {"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho",
+		"agosto", "setembro", "outubro", "novembro", "dezembro"},
+}
+
+// Soportado indica si hay catálogo para el idioma
+func (i Idioma) Soportado() bool {
+	_, ok := catalogo[i]
+	return ok
+}
+
+// Texto retorna el mensaje traducido; si falta, usa el español
+func (i Idioma) Texto(clave string, args ...any) string {
+	plantilla, ok := catalogo[i][clave]
+	if !ok {
+		plantilla, ok = catalogo[Espanol][clave]
+	}
+	if !ok {
+		return clave
+	}
+	return fmt.Sprintf(plantilla, args...)
+}
+
+// Plural retorna la palabra en singular o plural según n.
+// En portugués (CLDR) 0 y 1 usan singular; en español e inglés solo 1.
+func (i Idioma) Plural(clave string, n int) string {
+	formas, ok := plurales[i][clave]
+	if !ok {
+		formas, ok = plurales[Espanol][clave]
+	}
+	if !ok {
+		return clave
+	}
+	singular := n == 1
+	if i == Portugues {
+		singular = n == 0 || n == 1
+	}
+	if singular {
+		return formas[0]
+	}
+	return formas[1]
+}
+
+// FormatearFecha escribe la fecha según las costumbres del idioma
+func (i Idioma) FormatearFecha(fecha time.Time) string {
+	nombres, ok := meses[i]
+	if !ok {
+		nombres = meses[Espanol]
+	}
+	mes := nombres[fecha.Month()-1]
+	if i == Ingles {
+		return fmt.Sprintf("%s %d, %d", mes, fecha.Day(), fecha.Year())
+	}
+	return fmt.Sprintf("%d de %s de %d", fecha.Day(), mes, fecha.Year())
+}
+
+// CambiarIdioma define el idioma en que se atiende al usuario
+func (u *Usuario) CambiarIdioma(idioma Idioma) error {
+	if !idioma.Soportado() {
+		return nuevoError(CodigoDatoInvalido, "usuario", u.ID, "idioma", string(idioma),
+			"Idioma no soportado '%s'", idioma)
+	}
+	u.Idioma = idioma
+	return nil
+}
+
+// IdiomaDe retorna el idioma del usuario o el de la biblioteca
+func (b Biblioteca) IdiomaDe(usuarioID int) Idioma {
+	if usuario := b.BuscarUsuario(usuarioID); usuario != nil && usuario.Idioma.Soportado() {
+		return usuario.Idioma
+	}
+	return b.idioma()
+}
+
+// AvisoVencimiento arma el recordatorio de un préstamo en el idioma del usuario
+func (b Biblioteca) AvisoVencimiento(prestamo Prestamo) (string, error) {
+	usuario := b.BuscarUsuario(prestamo.UsuarioID)
+	if usuario == nil {
+		return "", errUsuarioNoEncontrado(prestamo.UsuarioID)
+	}
+	libro := b.BuscarLibro(prestamo.LibroID)
+	if libro == nil {
+		return "", errLibroNoEncontrado(prestamo.LibroID)
+	}
+	idioma := b.IdiomaDe(usuario.ID)
+	return idioma.Texto("aviso.vencimiento", usuario.Nombre, libro.Titulo,
+		idioma.FormatearFecha(prestamo.FechaDevolucion)), nil
+}
+
+// idioma retorna el idioma por defecto de la biblioteca
+func (b Biblioteca) idioma() Idioma {
+	if b.Idioma.Soportado() {
+		return b.Idioma
+	}
+	return Espanol
+}
+
+// InfoLibro retorna la información del libro en el idioma de la biblioteca
+func (b Biblioteca) InfoLibro(libro Libro) string {
+	return libro.ObtenerInfoEn(b.idioma())
+}
+
+// linea antepone el icono del mensaje, salvo en modo de salida plana
+func (b Biblioteca) linea(idioma Idioma, clave string, args ...any) string {
+	return decorar(idioma, b.SalidaPlana, clave, args...)
+}
+
+// decorar es linea para quien no tiene la biblioteca a mano
+func decorar(idioma Idioma, plano bool, clave string, args ...any) string {
+	texto := idioma.Texto(clave, args...)
+	if icono, ok := iconos[clave]; ok && !plano {
+		return icono + " " + texto
+	}
+	return texto
+}
+
+// separador retorna la línea divisoria de los listados
+func separador() string {
+	return "=" + strings.Repeat("=", 50)
+}
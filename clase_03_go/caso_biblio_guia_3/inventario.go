@@ -0,0 +1,257 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"biblio/codigobarras"
+)
+
+// ==========================================
+// INVENTARIO: TOMA FÍSICA DE ESTANTES
+// ==========================================
+
+// Lectura es un identificador escaneado en un estante
+type Lectura struct {
+	Estante       string
+	Identificador string // ISBN o ID del libro
+}
+
+// Discrepancia describe un libro cuyo estado físico no coincide con el catálogo
+type Discrepancia struct {
+	LibroID       int // 0 si el identificador no corresponde a ningún libro
+	Identificador string
+	Esperado      string
+	Encontrado    string
+}
+
+// ReporteInventario agrupa las discrepancias encontradas al cerrar la sesión
+type ReporteInventario struct {
+	Inicio             time.Time
+	Fin                time.Time
+	TotalEscaneados    int
+	Faltantes          []Discrepancia
+	Inesperados        []Discrepancia
+	MalUbicados        []Discrepancia
+	PrestadosEnEstante []Discrepancia
+
+	idioma Idioma // idioma y modo de salida de la biblioteca al cerrar
+	plano  bool
+}
+
+// SesionInventario acumula las lecturas de una toma de inventario.
+// Es segura para uso concurrente (varios lectores escaneando a la vez).
+type SesionInventario struct {
+	biblioteca *Biblioteca
+	inicio     time.Time
+	mu         sync.Mutex
+	lecturas   []Lectura
+	cerrada    bool
+}
+
+// AsignarUbicacion registra el estante donde debe estar el libro
+func (b *Biblioteca) AsignarUbicacion(libroID int, estante string) error {
+	libro := b.BuscarLibro(libroID)
+	if libro == nil {
+		return errLibroNoEncontrado(libroID)
+	}
+	libro.Ubicacion = strings.TrimSpace(estante)
+	return nil
+}
+
+// IniciarInventario abre una nueva sesión de toma de inventario
+func (b *Biblioteca) IniciarInventario() *SesionInventario {
+	return &SesionInventario{
+		biblioteca: b,
+		inicio:     b.ahora(),
+		lecturas:   make([]Lectura, 0),
+	}
+}
+
+// Escanear registra un identificador leído en un estante
+func (s *SesionInventario) Escanear(estante, identificador string) error {
+	identificador = strings.TrimSpace(identificador)
+	if identificador == "" {
+		return errDatoRequerido("inventario", "identificador", "Debe proporcionar un identificador")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cerrada {
+		return nuevoError(CodigoOperacionInvalida, "inventario", 0, "cerrada", "true",
+			"La sesión de inventario ya está cerrada")
+	}
+	s.lecturas = append(s.lecturas, Lectura{Estante: strings.TrimSpace(estante), Identificador: identificador})
+	return nil
+}
+
+// ProcesarLecturas consume lecturas del canal hasta que se cierre y
+// retorna cuántas se registraron
+func (s *SesionInventario) ProcesarLecturas(lecturas <-chan Lectura) int {
+	registradas := 0
+	for lectura := range lecturas {
+		if err := s.Escanear(lectura.Estante, lectura.Identificador); err == nil {
+			registradas++
+		}
+	}
+	return registradas
+}
+
+// Cerrar compara las lecturas con el catálogo y genera el reporte
+func (s *SesionInventario) Cerrar() ReporteInventario {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cerrada = true
+
+	b := s.biblioteca
+	reporte := ReporteInventario{
+		Inicio:          s.inicio,
+		Fin:             b.ahora(),
+		TotalEscaneados: len(s.lecturas),
+		idioma:          b.idioma(),
+		plano:           b.SalidaPlana,
+	}
+
+	// Un libro con préstamo activo no se espera en el estante
+	enPrestamo := make(map[int]bool)
+	for _, prestamo := range b.Prestamos {
+		if !prestamo.Devuelto {
+			enPrestamo[prestamo.LibroID] = true
+		}
+	}
+
+	vistos := make(map[int]bool)
+	for _, lectura := range s.lecturas {
+		libro := b.buscarPorIdentificador(lectura.Identificador)
+		if libro == nil {
+			reporte.Inesperados = append(reporte.Inesperados, Discrepancia{
+				Identificador: lectura.Identificador,
+				Esperado:      "no catalogado",
+				Encontrado:    lectura.Estante,
+			})
+			continue
+		}
+		if vistos[libro.ID] {
+			continue
+		}
+		vistos[libro.ID] = true
+
+		discrepancia := Discrepancia{
+			LibroID:       libro.ID,
+			Identificador: lectura.Identificador,
+			Esperado:      libro.Ubicacion,
+			Encontrado:    lectura.Estante,
+		}
+		switch {
+		case enPrestamo[libro.ID]:
+			discrepancia.Esperado = "prestado"
+			reporte.PrestadosEnEstante = append(reporte.PrestadosEnEstante, discrepancia)
+		case libro.Estado != EnCirculacion:
+			discrepancia.Esperado = string(libro.Estado)
+			reporte.Inesperados = append(reporte.Inesperados, discrepancia)
+		case libro.Ubicacion != "" && lectura.Estante != libro.Ubicacion:
+			reporte.MalUbicados = append(reporte.MalUbicados, discrepancia)
+		}
+	}
+
+	for _, libro := range b.Libros {
+		if vistos[libro.ID] || enPrestamo[libro.ID] || libro.Estado != EnCirculacion {
+			continue
+		}
+		identificador := libro.ISBN
+		if identificador == "" {
+			identificador = strconv.Itoa(libro.ID)
+		}
+		reporte.Faltantes = append(reporte.Faltantes, Discrepancia{
+			LibroID:       libro.ID,
+			Identificador: identificador,
+			Esperado:      libro.Ubicacion,
+			Encontrado:    "no escaneado",
+		})
+	}
+
+	sort.Slice(reporte.Faltantes, func(i, j int) bool {
+		return reporte.Faltantes[i].LibroID < reporte.Faltantes[j].LibroID
+	})
+	return reporte
+}
+
+// TieneDiscrepancias indica si el inventario encontró algún problema
+func (r ReporteInventario) TieneDiscrepancias() bool {
+	return len(r.Faltantes)+len(r.Inesperados)+len(r.MalUbicados)+len(r.PrestadosEnEstante) > 0
+}
+
+// Resumen retorna el reporte en texto legible, en el idioma de la biblioteca
+func (r ReporteInventario) Resumen() string {
+	idioma := r.idioma
+	if !idioma.Soportado() {
+		idioma = Espanol
+	}
+	var sb strings.Builder
+	sb.WriteString(decorar(idioma, r.plano, "inventario.titulo",
+		r.TotalEscaneados, idioma.Plural("lectura", r.TotalEscaneados)) + "\n")
+	secciones := []struct {
+		clave string
+		items []Discrepancia
+	}{
+		{"inventario.faltantes", r.Faltantes},
+		{"inventario.inesperados", r.Inesperados},
+		{"inventario.mal_ubicados", r.MalUbicados},
+		{"inventario.prestados", r.PrestadosEnEstante},
+	}
+	for _, seccion := range secciones {
+		sb.WriteString(decorar(idioma, r.plano, seccion.clave, len(seccion.items)) + "\n")
+		for _, d := range seccion.items {
+			fmt.Fprintf(&sb, "   %s\n", idioma.Texto("inventario.item", d.LibroID, d.Identificador,
+				traducirMarca(idioma, d.Esperado), traducirMarca(idioma, d.Encontrado)))
+		}
+	}
+	return sb.String()
+}
+
+// traducirMarca traduce los valores fijos de una discrepancia ("no
+// escaneado", "prestado", un estado del libro); los estantes quedan igual
+func traducirMarca(idioma Idioma, valor string) string {
+	for _, prefijo := range []string{"inventario.", "estado."} {
+		if _, ok := catalogo[Espanol][prefijo+valor]; ok {
+			return idioma.Texto(prefijo + valor)
+		}
+	}
+	return valor
+}
+
+// buscarPorIdentificador resuelve un código escaneado: la etiqueta de
+// lomo ("L000123"), el ISBN con o sin guiones, su EAN-13 o el ID
+func (b *Biblioteca) buscarPorIdentificador(identificador string) *Libro {
+	identificador = strings.TrimSpace(identificador)
+	if resto, ok := strings.CutPrefix(strings.ToUpper(identificador), "L"); ok {
+		if id, err := strconv.Atoi(resto); err == nil {
+			return b.BuscarLibro(id)
+		}
+	}
+
+	escaneado := normalizarISBN(identificador)
+	ean, errEAN := codigobarras.ISBNaEAN13(identificador)
+	for i := range b.Libros {
+		isbn := b.Libros[i].ISBN
+		if isbn == "" {
+			continue
+		}
+		if normalizarISBN(isbn) == escaneado {
+			return &b.Libros[i]
+		}
+		if errEAN == nil {
+			if otro, err := codigobarras.ISBNaEAN13(isbn); err == nil && otro == ean {
+				return &b.Libros[i]
+			}
+		}
+	}
+	if id, err := strconv.Atoi(identificador); err == nil {
+		return b.BuscarLibro(id)
+	}
+	return nil
+}
@@ -2,8 +2,12 @@ package main
 
 import (
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
 	"time"
+
+	"FyS_proyect/telefono"
 )
 
 // ==========================================
@@ -11,21 +15,27 @@ import (
 // ==========================================
 // Libro representa un libro en la biblioteca
 type Libro struct {
-	ID       int
-	Titulo   string
-	Autor    string
-	ISBN     string
-	Paginas  int
-	Prestado bool
+	ID        int
+	Titulo    string
+	Autor     string
+	ISBN      string
+	Paginas   int
+	Prestado  bool
+	Estado    EstadoLibro
+	Ubicacion string // estante donde se guarda el libro
+	Categoria string
 }
 
 // Usuario representa un usuario de la biblioteca
 type Usuario struct {
-	ID       int
-	Nombre   string
-	Email    string
-	Telefono string
-	Activo   bool
+	ID          int
+	Nombre      string
+	Email       string
+	Telefono    string
+	Activo      bool
+	Idioma      Idioma // vacío: se usa el idioma de la biblioteca
+	Anonimizado bool   // datos personales borrados a pedido del usuario
+	Categoria   string // "estudiante", "docente"... usada por las políticas
 }
 
 // Prestamo representa un prestamo de un libro
@@ -35,27 +45,40 @@ type Prestamo struct {
 	UsuarioID       int
 	FechaPrestamo   time.Time
 	FechaDevolucion time.Time
-	Devuelto        bool
+	Devuelto        bool // true cuando el préstamo está cerrado
+	Perdido         bool // el préstamo se cerró por pérdida del libro
+	DiasPrestamo    int
+	Renovaciones    int
+	MaxRenovaciones int
+	MultaDiaria     float64 // tarifa fijada por la política al prestar
 }
 
 // ==========================================
 // PASO 2: MÉTODOS CON RECEPTOR DE VALOR
 // (Solo para LEER información, no modifican)
 // ==========================================
-// ObtenerInfo retorna información básica del libro
+// ObtenerInfo retorna información básica del libro en español;
+// Biblioteca.InfoLibro usa el idioma configurado
 // Usa receptor de VALOR porque solo LEE, no modifica
 func (l Libro) ObtenerInfo() string {
-	estado := "Disponible"
+	return l.ObtenerInfoEn(Espanol)
+}
+
+// ObtenerInfoEn retorna la información básica del libro en el idioma dado
+func (l Libro) ObtenerInfoEn(idioma Idioma) string {
+	estado := idioma.Texto("estado.disponible")
 	if l.Prestado {
-		estado = "Prestado"
+		estado = idioma.Texto("estado.prestado")
+	} else if l.Estado != EnCirculacion {
+		estado = idioma.Texto("estado." + string(l.Estado))
 	}
-	return fmt.Sprintf("[%d] %s por %s - %s", l.ID, l.Titulo, l.Autor, estado)
+	return idioma.Texto("libro.info", l.ID, l.Titulo, l.Autor, estado)
 }
 
 // EsPretable verifica si el libro se puede prestar
 // Usa receptor de VALOR porque solo LEE
 func (l Libro) EsPrestable() bool {
-	return !l.Prestado && l.Paginas > 0
+	return !l.Prestado && l.Paginas > 0 && l.Estado == EnCirculacion
 }
 
 func (l Libro) EsGrande() bool {
@@ -86,10 +109,16 @@ func (u Usuario) PuedePrestar() bool {
 
 func (l *Libro) Prestar() error {
 	if l.Prestado {
-		return fmt.Errorf("El libro '%s' ya está prestado", l.Titulo)
+		return nuevoError(CodigoLibroYaPrestado, "libro", l.ID, "prestado", "true",
+			"El libro '%s' ya está prestado", l.Titulo)
 	}
 	if l.Paginas <= 0 {
-		return fmt.Errorf("El libro '%s' no es valido", l.Titulo)
+		return nuevoError(CodigoDatoInvalido, "libro", l.ID, "paginas", strconv.Itoa(l.Paginas),
+			"El libro '%s' no es valido", l.Titulo)
+	}
+	if l.Estado != EnCirculacion {
+		return nuevoError(CodigoLibroNoDisponible, "libro", l.ID, "estado", string(l.Estado),
+			"El libro '%s' está %s", l.Titulo, l.Estado)
 	}
 	l.Prestado = true
 	return nil
@@ -97,7 +126,8 @@ func (l *Libro) Prestar() error {
 
 func (l *Libro) Devolver() error {
 	if !l.Prestado {
-		return fmt.Errorf("El libro '%s' no está prestado", l.Titulo)
+		return nuevoError(CodigoLibroNoPrestado, "libro", l.ID, "prestado", "false",
+			"El libro '%s' no está prestado", l.Titulo)
 	}
 	l.Prestado = false
 	return nil
@@ -107,10 +137,10 @@ func (l *Libro) Devolver() error {
 // Usa receptor de PUNTERO porque MODIFICA el estado
 func (l *Libro) ActualizarInfo(titulo, autor string, paginas int) error {
 	if titulo == "" || autor == "" {
-		return fmt.Errorf("Debe proporcionar titulo y autor")
+		return errDatoRequerido("libro", campoVacio("titulo", titulo, "autor"), "Debe proporcionar titulo y autor")
 	}
 	if paginas <= 0 {
-		return fmt.Errorf("Debe proporcionar cantidad de paginas")
+		return errDatoRequerido("libro", "paginas", "Debe proporcionar cantidad de paginas")
 	}
 
 	l.Titulo = titulo
@@ -127,41 +157,82 @@ func (u *Usuario) Desactivar() {
 	u.Activo = false
 }
 
-func (u *Usuario) ActualizarContacto(email, telefono string) error {
+func (u *Usuario) ActualizarContacto(email, numero string) error {
 	if !strings.Contains(email, "@") {
-		return fmt.Errorf("Email no válido '%s'", email)
+		return nuevoError(CodigoDatoInvalido, "usuario", u.ID, "email", email,
+			"Email no válido '%s'", email)
+	}
+	normalizado, err := normalizarTelefonoE164(u.ID, numero)
+	if err != nil {
+		return err
 	}
 	u.Email = email
-	u.Telefono = telefono
+	u.Telefono = normalizado
 	return nil
 }
 
+// paisTelefono es el país de los teléfonos que se escriben sin +código
+const paisTelefono = "PE"
+
+// normalizarTelefonoE164 valida el teléfono y lo deja en formato E.164;
+// el teléfono es opcional, así que vacío no es error
+func normalizarTelefonoE164(usuarioID int, numero string) (string, error) {
+	if strings.TrimSpace(numero) == "" {
+		return "", nil
+	}
+	normalizado, err := telefono.Normalizar(numero, paisTelefono)
+	if err != nil {
+		return "", nuevoError(CodigoDatoInvalido, "usuario", usuarioID, "telefono", numero, "%v", err)
+	}
+	return normalizado, nil
+}
+
 // ==========================================
 // PASO 4: STRUCT PRINCIPAL CON COMPOSICIÓN
 // ==========================================
 // Biblioteca es el struct principal que maneja todo el sistema
 
 type Biblioteca struct {
-	Nombre    string
-	Direccion string
-	Libros    []Libro
-	Usuarios  []Usuario
-	Prestamos []Prestamo
-	proximoID int
+	Nombre      string
+	Direccion   string
+	Libros      []Libro
+	Usuarios    []Usuario
+	Prestamos   []Prestamo
+	Multas      []Multa
+	Calendario  *Calendario // nil: abre todos los días
+	MultaDiaria float64     // monto por día de atención con retraso
+	Idioma      Idioma      // idioma por defecto de los mensajes
+	SalidaPlana bool        // sin emojis, para terminales y logs
+	Reloj       Reloj       // nil: se usa la hora del sistema
+	Politicas   *MotorPoliticas
+	proximoID   int
+
+	suscriptores     []ManejadorEventos
+	secuenciaEventos int
+	anonimizados     []int // IDs borrados; se vuelven a borrar al restaurar
 }
 
+// diasPrestamo es el plazo estándar de un préstamo
+const diasPrestamo = 14
+
+// rutaPoliticas es el archivo de políticas que se carga al iniciar, si existe
+const rutaPoliticas = "politicas.json"
+
 // ==========================================
 // PASO 5: MÉTODOS AVANZADOS CON LÓGICA DE NEGOCIO
 // ==========================================
 // NuevaBiblioteca es un constructor (patrón común en Go)
 func NuevaBiblioteca(nombre, direccion string) *Biblioteca {
 	return &Biblioteca{
-		Nombre:    nombre,
-		Direccion: direccion,
-		Libros:    make([]Libro, 0),
-		Usuarios:  make([]Usuario, 0),
-		Prestamos: make([]Prestamo, 0),
-		proximoID: 1,
+		Nombre:      nombre,
+		Direccion:   direccion,
+		Libros:      make([]Libro, 0),
+		Usuarios:    make([]Usuario, 0),
+		Prestamos:   make([]Prestamo, 0),
+		Multas:      make([]Multa, 0),
+		MultaDiaria: 0.5,
+		Idioma:      Espanol,
+		proximoID:   1,
 	}
 }
 
@@ -169,13 +240,14 @@ func NuevaBiblioteca(nombre, direccion string) *Biblioteca {
 // Usa receptor de PUNTERO porque modifica el slice de libros
 func (b *Biblioteca) AgregarLibro(titulo, autor, isbn string, paginas int) (*Libro, error) {
 	if titulo == "" || autor == "" {
-		return nil, fmt.Errorf("Debe proporcionar titulo y autor")
+		return nil, errDatoRequerido("libro", campoVacio("titulo", titulo, "autor"), "Debe proporcionar titulo y autor")
 	}
 
 	//verificar que no exista un lubro con el mismo ISBN
 	for _, libro := range b.Libros {
-		if libro.ISBN == isbn && isbn != "" {
-			return nil, fmt.Errorf("Ya existe un libro con el ISBN '%s'", isbn)
+		if isbn != "" && normalizarISBN(libro.ISBN) == normalizarISBN(isbn) {
+			return nil, nuevoError(CodigoDuplicado, "libro", libro.ID, "isbn", isbn,
+				"Ya existe un libro con el ISBN '%s'", isbn)
 		}
 	}
 
@@ -186,40 +258,50 @@ func (b *Biblioteca) AgregarLibro(titulo, autor, isbn string, paginas int) (*Lib
 		ISBN:     isbn,
 		Paginas:  paginas,
 		Prestado: false,
+		Estado:   EnCirculacion,
 	}
 
 	b.Libros = append(b.Libros, libro)
 	b.proximoID++
+	b.publicar(Evento{Tipo: EventoLibroAgregado, LibroID: libro.ID})
 
 	return &libro, nil
 }
 
 // RegistrarUsuario registra un nuevo usuario
 // Usa receptor de PUNTERO porque modifica el slice de usuarios
-func (b *Biblioteca) RegistrarUsuario(nombre, email, telefono string) (*Usuario, error) {
+func (b *Biblioteca) RegistrarUsuario(nombre, email, numero string) (*Usuario, error) {
 	if nombre == "" || email == "" {
-		return nil, fmt.Errorf("Debe proporcionar nombre y email")
+		return nil, errDatoRequerido("usuario", campoVacio("nombre", nombre, "email"), "Debe proporcionar nombre y email")
 	}
 
 	if !strings.Contains(email, "@") {
-		return nil, fmt.Errorf("Email no válido '%s'", email)
+		return nil, nuevoError(CodigoDatoInvalido, "usuario", 0, "email", email,
+			"Email no válido '%s'", email)
+	}
+
+	telefonoE164, err := normalizarTelefonoE164(0, numero)
+	if err != nil {
+		return nil, err
 	}
 
 	for _, usuario := range b.Usuarios {
-		if usuario.Email == email {
-			return nil, fmt.Errorf("Ya existe un usuario con el email '%s'", email)
+		if strings.EqualFold(usuario.Email, email) {
+			return nil, nuevoError(CodigoDuplicado, "usuario", usuario.ID, "email", email,
+				"Ya existe un usuario con el email '%s'", email)
 		}
 	}
 	usuario := Usuario{
 		ID:       b.proximoID,
 		Nombre:   nombre,
 		Email:    email,
-		Telefono: telefono,
+		Telefono: telefonoE164,
 		Activo:   true,
 	}
 
 	b.Usuarios = append(b.Usuarios, usuario)
 	b.proximoID++
+	b.publicar(Evento{Tipo: EventoUsuarioRegistrado, UsuarioID: usuario.ID})
 
 	return &usuario, nil
 }
@@ -252,36 +334,57 @@ func (b *Biblioteca) PrestarLibro(libroID, usuarioID int) error {
 	//Buscar libro
 	libro := b.BuscarLibro(libroID)
 	if libro == nil {
-		return fmt.Errorf("No existe un libro con ID '%d'", libroID)
+		return errLibroNoEncontrado(libroID)
 	}
 
 	// Buscar Usuario
 	usuario := b.BuscarUsuario(usuarioID)
 	if usuario == nil {
-		return fmt.Errorf("No existe un usuario con ID '%d'", usuarioID)
+		return errUsuarioNoEncontrado(usuarioID)
 	}
 
 	// validar que el usuario pueda prestar
 	if !usuario.PuedePrestar() {
-		return fmt.Errorf("El usuario '%s' no puede prestar", usuario.Nombre)
+		return nuevoError(CodigoUsuarioNoHabilitado, "usuario", usuario.ID, "activo", strconv.FormatBool(usuario.Activo),
+			"El usuario '%s' no puede prestar", usuario.Nombre)
 	}
 
 	// validar que el libro se puede prestar
+	if libro.Prestado {
+		return nuevoError(CodigoLibroYaPrestado, "libro", libro.ID, "prestado", "true",
+			"El libro '%s' ya está prestado", libro.Titulo)
+	}
 	if !libro.EsPrestable() {
-		return fmt.Errorf("El libro '%s' no se puede prestar", libro.Titulo)
+		return nuevoError(CodigoLibroNoDisponible, "libro", libro.ID, "estado", string(libro.Estado),
+			"El libro '%s' no se puede prestar", libro.Titulo)
+	}
+
+	// validar las políticas de préstamo
+	decision := b.motorPoliticas().Evaluar(*b, *usuario, *libro)
+	if !decision.Permitido {
+		return nuevoError(CodigoPrestamoDenegado, "libro", libro.ID, "usuario", strconv.Itoa(usuario.ID),
+			"%s", decision.Explicar())
 	}
 
 	// Realizar el prestamo
+	if err := libro.Prestar(); err != nil {
+		return err
+	}
+	ahora := b.ahora()
 	prestamo := Prestamo{
 		ID:              b.proximoID,
 		LibroID:         libroID,
 		UsuarioID:       usuarioID,
-		FechaPrestamo:   time.Now(),
-		FechaDevolucion: time.Now().AddDate(0, 0, 14), // 14 dias
+		FechaPrestamo:   ahora,
+		FechaDevolucion: b.calcularVencimiento(ahora, decision.DiasPrestamo),
 		Devuelto:        false,
+		DiasPrestamo:    decision.DiasPrestamo,
+		MaxRenovaciones: decision.MaxRenovaciones,
+		MultaDiaria:     decision.MultaDiaria,
 	}
 	b.Prestamos = append(b.Prestamos, prestamo)
 	b.proximoID++
+	b.publicar(Evento{Tipo: EventoLibroPrestado, LibroID: libroID, UsuarioID: usuarioID, PrestamoID: prestamo.ID})
 
 	return nil
 }
@@ -292,19 +395,14 @@ func (b *Biblioteca) DevolverLibro(libroID int) error {
 	//Buscar libro
 	libro := b.BuscarLibro(libroID)
 	if libro == nil {
-		return fmt.Errorf("No existe un libro con ID '%d'", libroID)
+		return errLibroNoEncontrado(libroID)
 	}
 
 	// Buscar prestamo activo
-	var prestamoActivo *Prestamo
-	for i := range b.Prestamos {
-		if b.Prestamos[i].LibroID == libroID && !b.Prestamos[i].Devuelto {
-			prestamoActivo = &b.Prestamos[i]
-			break
-		}
-	}
+	prestamoActivo := b.buscarPrestamoActivo(libroID)
 	if prestamoActivo == nil {
-		return fmt.Errorf("No existe un prestamo activo para el libro '%s'", libro.Titulo)
+		return nuevoError(CodigoPrestamoNoEncontrado, "libro", libro.ID, "", "",
+			"No existe un prestamo activo para el libro '%s'", libro.Titulo)
 	}
 
 	// Realizar la devolucion
@@ -314,6 +412,25 @@ func (b *Biblioteca) DevolverLibro(libroID int) error {
 
 	// Marcar prestamo como devuelto
 	prestamoActivo.Devuelto = true
+	b.publicar(Evento{Tipo: EventoLibroDevuelto, LibroID: libroID,
+		UsuarioID: prestamoActivo.UsuarioID, PrestamoID: prestamoActivo.ID})
+
+	// Cobrar el retraso, contando solo los días de atención
+	ahora := b.ahora()
+	if monto := b.CalcularMultaRetraso(*prestamoActivo, ahora); monto > 0 {
+		multa := Multa{
+			ID:         b.proximoID,
+			UsuarioID:  prestamoActivo.UsuarioID,
+			PrestamoID: prestamoActivo.ID,
+			Motivo:     fmt.Sprintf("Retraso en la devolución de '%s'", libro.Titulo),
+			Monto:      monto,
+			Fecha:      ahora,
+		}
+		b.Multas = append(b.Multas, multa)
+		b.proximoID++
+		b.publicar(Evento{Tipo: EventoMultaGenerada, LibroID: libroID, UsuarioID: multa.UsuarioID,
+			PrestamoID: multa.PrestamoID, MultaID: multa.ID})
+	}
 
 	return nil
 }
@@ -321,14 +438,27 @@ func (b *Biblioteca) DevolverLibro(libroID int) error {
 // ObtenerEstadisticas retorna estadísticas de la biblioteca
 // Usa receptor de VALOR porque solo lee información
 func (b Biblioteca) ObtenerEstadisticas() string {
-	totalLibros := len(b.Libros)
+	return b.ObtenerEstadisticasEn(b.idioma())
+}
+
+// ObtenerEstadisticasEn retorna las estadísticas en el idioma dado
+func (b Biblioteca) ObtenerEstadisticasEn(idioma Idioma) string {
+	totalLibros := 0
 	librosPrestados := 0
+	librosNoPrestables := 0
 	usuariosActivos := 0
 	prestamosActivos := 0
 
+	// Los libros dados de baja se conservan por historial pero no cuentan
 	for _, libro := range b.Libros {
+		if libro.Estado == DadoDeBaja {
+			continue
+		}
+		totalLibros++
 		if libro.Prestado {
 			librosPrestados++
+		} else if libro.Estado != EnCirculacion {
+			librosNoPrestables++
 		}
 	}
 
@@ -343,34 +473,39 @@ func (b Biblioteca) ObtenerEstadisticas() string {
 			prestamosActivos++
 		}
 	}
-	return fmt.Sprintf(`📊 Estadísticas de %s: 
-		📚 Total de libros: %d
-		📖 Libros prestados: %d
-		📕 Libros disponibles: %d
-		👥 Usuarios activos: %d
-		📋 Préstamos activos: %d`, b.Nombre, totalLibros, librosPrestados, totalLibros-librosPrestados, usuariosActivos, prestamosActivos)
+	lineas := []string{
+		b.linea(idioma, "estadisticas.titulo", b.Nombre),
+		b.linea(idioma, "estadisticas.total", totalLibros),
+		b.linea(idioma, "estadisticas.prestados", librosPrestados),
+		b.linea(idioma, "estadisticas.disponibles", totalLibros-librosPrestados-librosNoPrestables),
+		b.linea(idioma, "estadisticas.no_prestable", librosNoPrestables),
+		b.linea(idioma, "estadisticas.usuarios", usuariosActivos),
+		b.linea(idioma, "estadisticas.prestamos", prestamosActivos),
+	}
+	return strings.Join(lineas, "\n\t\t")
 }
 
 // ListarLibrosDisponibles muestra todos los libros disponibles
 // Usa receptor de VALOR porque solo lee
 func (b Biblioteca) ListarLibrosDisponibles() {
-	fmt.Println("📚 Libros disponibles:")
-	fmt.Println("=" + strings.Repeat("=", 50))
+	idioma := b.idioma()
+	fmt.Println(b.linea(idioma, "listado.titulo"))
+	fmt.Println(separador())
 
 	disponibles := 0
 	for _, libro := range b.Libros {
-		if !libro.Prestado {
-			fmt.Printf(" %s\n", libro.ObtenerInfo())
+		if !libro.Prestado && libro.Estado == EnCirculacion {
+			fmt.Printf(" %s\n", libro.ObtenerInfoEn(idioma))
 			if libro.EsGrande() {
-				fmt.Printf("     📖 Libro extenso (%d páginas)\n",
-					libro.Paginas)
+				fmt.Printf("     %s\n", b.linea(idioma, "listado.extenso",
+					libro.Paginas, idioma.Plural("pagina", libro.Paginas)))
 			}
+			disponibles++
 		}
-		disponibles++
 	}
 
 	if disponibles == 0 {
-		fmt.Println(" No hay libros disponibles")
+		fmt.Printf(" %s\n", b.linea(idioma, "listado.vacio"))
 	}
 }
 
@@ -378,12 +513,39 @@ func (b Biblioteca) ListarLibrosDisponibles() {
 // FUNCIÓN PRINCIPAL DEMOSTRATIVA
 // ==========================================
 func main() {
+	// go run . escenario guion1.txt guion2.txt ...
+	if len(os.Args) > 2 && os.Args[1] == "escenario" {
+		os.Exit(ejecutarEscenariosCLI(os.Args[2:]))
+	}
+	// go run . mostrador
+	if len(os.Args) > 1 && os.Args[1] == "mostrador" {
+		os.Exit(ejecutarMostradorCLI())
+	}
+	// go run . grpc :50051
+	if len(os.Args) > 2 && os.Args[1] == "grpc" {
+		biblioteca := NuevaBiblioteca("Biblioteca Central", "Av. Principal 123")
+		if err := biblioteca.CargarPoliticasOpcionales(rutaPoliticas); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("🛰 Servidor gRPC escuchando en %s\n", os.Args[2])
+		if err := NuevoServidorGRPC(biblioteca).Servir(os.Args[2]); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	fmt.Println("🏛 SISTEMA DE BIBLIOTECA - DEMO PRÁCTICA")
 	fmt.Println("=" + strings.Repeat("=", 50))
 
 	// PASO 1: Crear biblioteca
 	biblioteca := NuevaBiblioteca("Biblioteca Central", "Av. Principal 123")
 	fmt.Printf("\n✅ Biblioteca creada: %s\n", biblioteca.Nombre)
+	if err := biblioteca.CargarPoliticasOpcionales(rutaPoliticas); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
 
 	// PASO 2: Agregar libros
 	fmt.Println("\n📚 Agregando libros...")
@@ -402,7 +564,7 @@ func main() {
 		if err != nil {
 			fmt.Printf("❌ Error al agregar libro: %s\n", err)
 		} else {
-			fmt.Printf("✅ Agregado libro: %s\n", libro.ObtenerInfo())
+			fmt.Printf("✅ Agregado libro: %s\n", biblioteca.InfoLibro(*libro))
 		}
 	}
 
@@ -410,16 +572,19 @@ func main() {
 	fmt.Println("\n👥 Registrando usuarios...")
 
 	usuarios := []struct {
-		nombre, email, telefono string
+		nombre, email, telefono, categoria string
 	}{
-		{"Bryan", "carlos@gmail.com", "+51 999 999 999"},
-		{"Claudia", "claudia@gmail.com", "+56 999 999 999"},
-		{"Juan", "juan@gmail.com", "+56 999 999 999"},
-		{"Myson", "myson@gmail.com", "+56 999 999 999"},
+		{"Bryan", "carlos@gmail.com", "+51 999 999 999", "estudiante"},
+		{"Claudia", "claudia@gmail.com", "+56 999 999 999", "docente"},
+		{"Juan", "juan@gmail.com", "+56 999 999 999", "estudiante"},
+		{"Myson", "myson@gmail.com", "+56 999 999 999", "estudiante"},
 	}
 
 	for _, u := range usuarios {
 		usuario, err := biblioteca.RegistrarUsuario(u.nombre, u.email, u.telefono)
+		if err == nil {
+			err = biblioteca.AsignarCategoriaUsuario(usuario.ID, u.categoria)
+		}
 		if err != nil {
 			fmt.Printf("❌ Error al registrar usuario: %s\n", err)
 		} else {
@@ -469,14 +634,14 @@ func main() {
 	fmt.Println("=" + strings.Repeat("=", 50))
 
 	libro := biblioteca.BuscarLibro(4) // Clean Code
-	fmt.Printf("Estado inicial: %s\n", libro.ObtenerInfo())
+	fmt.Printf("Estado inicial: %s\n", biblioteca.InfoLibro(*libro))
 
 	// Intentar prestar (modifica el struct)
 	err = libro.Prestar()
 	if err != nil {
 		fmt.Printf("❌ Error al prestar libro: %s\n", err)
 	} else {
-		fmt.Printf("Despues del prestamo: %s\n", libro.ObtenerInfo())
+		fmt.Printf("Despues del prestamo: %s\n", biblioteca.InfoLibro(*libro))
 	}
 
 	// Verificar info (no modificada)
@@ -489,4 +654,4 @@ func main() {
 	fmt.Println(" • Validaciones y manejo de errores")
 	fmt.Println(" • Lógica de negocio completa")
 
-}
\ No newline at end of file
+}
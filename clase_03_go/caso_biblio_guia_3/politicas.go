@@ -0,0 +1,301 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// ==========================================
+// POLÍTICAS DE PRÉSTAMO CONFIGURABLES
+// ==========================================
+
+// Condiciones de préstamo que puede fijar una regla. Los punteros
+// distinguen "no lo cambia" (nil) de un valor explícito.
+type Condiciones struct {
+	Permitido       *bool    `json:"permitido,omitempty"`
+	DiasPrestamo    *int     `json:"dias_prestamo,omitempty"`
+	MaxRenovaciones *int     `json:"max_renovaciones,omitempty"`
+	MultaDiaria     *float64 `json:"multa_diaria,omitempty"`
+	MaxPrestamos    *int     `json:"max_prestamos,omitempty"` // préstamos activos por usuario
+	Motivo          string   `json:"motivo,omitempty"`
+}
+
+// Regla aplica sus condiciones cuando coinciden todos sus filtros;
+// un filtro vacío acepta cualquier valor
+type Regla struct {
+	Nombre            string   `json:"nombre"`
+	CategoriasUsuario []string `json:"usuario_categoria,omitempty"`
+	CategoriasLibro   []string `json:"libro_categoria,omitempty"`
+	Tamano            string   `json:"tamano,omitempty"` // "grande" o "normal"
+	Sedes             []string `json:"sede,omitempty"`   // nombre de la biblioteca
+	Condiciones
+}
+
+// Politica es el contenido del archivo de políticas
+type Politica struct {
+	Predeterminada Condiciones `json:"predeterminada"`
+	Reglas         []Regla     `json:"reglas"`
+}
+
+// Decision es el resultado de evaluar un préstamo
+type Decision struct {
+	Permitido       bool
+	DiasPrestamo    int
+	MaxRenovaciones int
+	MultaDiaria     float64
+	ReglasAplicadas []string
+	Motivos         []string // por qué se niega, si se niega
+}
+
+// MotorPoliticas evalúa las reglas en orden; cada regla que coincide
+// sobrescribe los valores que define
+type MotorPoliticas struct {
+	politica Politica
+}
+
+// NuevoMotorPoliticas crea un motor a partir de una política ya armada
+func NuevoMotorPoliticas(politica Politica) *MotorPoliticas {
+	return &MotorPoliticas{politica: politica}
+}
+
+// CargarPoliticas lee un archivo JSON de políticas
+func CargarPoliticas(ruta string) (*MotorPoliticas, error) {
+	contenido, err := os.ReadFile(ruta)
+	if err != nil {
+		return nil, fmt.Errorf("No se pudo leer el archivo de políticas: %w", err)
+	}
+	var politica Politica
+	if err := json.Unmarshal(contenido, &politica); err != nil {
+		return nil, fmt.Errorf("Archivo de políticas no válido: %w", err)
+	}
+	if err := politica.Validar(); err != nil {
+		return nil, err
+	}
+	return NuevoMotorPoliticas(politica), nil
+}
+
+// Validar revisa que las reglas tengan nombre y valores posibles
+func (p Politica) Validar() error {
+	if err := p.Predeterminada.validar(); err != nil {
+		return fmt.Errorf("La política predeterminada %w", err)
+	}
+	for i, regla := range p.Reglas {
+		if regla.Nombre == "" {
+			return fmt.Errorf("La regla %d no tiene nombre", i+1)
+		}
+		if regla.Tamano != "" && regla.Tamano != "grande" && regla.Tamano != "normal" {
+			return fmt.Errorf("La regla '%s' tiene un tamaño no válido '%s'", regla.Nombre, regla.Tamano)
+		}
+		if err := regla.Condiciones.validar(); err != nil {
+			return fmt.Errorf("La regla '%s' %w", regla.Nombre, err)
+		}
+	}
+	return nil
+}
+
+func (c Condiciones) validar() error {
+	if c.DiasPrestamo != nil && *c.DiasPrestamo <= 0 {
+		return fmt.Errorf("tiene dias_prestamo no válido (%d); debe ser mayor que 0", *c.DiasPrestamo)
+	}
+	if c.MaxRenovaciones != nil && *c.MaxRenovaciones < 0 {
+		return fmt.Errorf("tiene max_renovaciones negativo (%d)", *c.MaxRenovaciones)
+	}
+	if c.MultaDiaria != nil && *c.MultaDiaria < 0 {
+		return fmt.Errorf("tiene multa_diaria negativa (%.2f)", *c.MultaDiaria)
+	}
+	if c.MaxPrestamos != nil && *c.MaxPrestamos < 0 {
+		return fmt.Errorf("tiene max_prestamos negativo (%d)", *c.MaxPrestamos)
+	}
+	return nil
+}
+
+// CargarPoliticasOpcionales configura el motor desde el archivo si
+// existe; sin archivo la biblioteca sigue con las condiciones estándar
+func (b *Biblioteca) CargarPoliticasOpcionales(ruta string) error {
+	if _, err := os.Stat(ruta); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	motor, err := CargarPoliticas(ruta)
+	if err != nil {
+		return err
+	}
+	b.Politicas = motor
+	return nil
+}
+
+// Evaluar decide si el usuario puede llevarse el libro y en qué condiciones
+func (m *MotorPoliticas) Evaluar(b Biblioteca, usuario Usuario, libro Libro) Decision {
+	decision := Decision{
+		Permitido:       true,
+		DiasPrestamo:    diasPrestamo,
+		MaxRenovaciones: 1,
+		MultaDiaria:     b.MultaDiaria,
+	}
+	maxPrestamos := 0 // 0: sin límite
+	motivoPolitica := ""
+
+	aplicar := func(nombre string, c Condiciones) {
+		if c.Permitido != nil {
+			decision.Permitido = *c.Permitido
+			if !*c.Permitido {
+				motivoPolitica = fmt.Sprintf("Regla '%s'", nombre)
+				if c.Motivo != "" {
+					motivoPolitica += ": " + c.Motivo
+				}
+			}
+		}
+		if c.DiasPrestamo != nil {
+			decision.DiasPrestamo = *c.DiasPrestamo
+		}
+		if c.MaxRenovaciones != nil {
+			decision.MaxRenovaciones = *c.MaxRenovaciones
+		}
+		if c.MultaDiaria != nil {
+			decision.MultaDiaria = *c.MultaDiaria
+		}
+		if c.MaxPrestamos != nil {
+			maxPrestamos = *c.MaxPrestamos
+		}
+	}
+
+	aplicar("predeterminada", m.politica.Predeterminada)
+	for _, regla := range m.politica.Reglas {
+		if regla.coincide(b, usuario, libro) {
+			aplicar(regla.Nombre, regla.Condiciones)
+			decision.ReglasAplicadas = append(decision.ReglasAplicadas, regla.Nombre)
+		}
+	}
+
+	if !decision.Permitido {
+		decision.Motivos = append(decision.Motivos, motivoPolitica)
+	}
+	if !usuario.PuedePrestar() {
+		decision.Permitido = false
+		decision.Motivos = append(decision.Motivos, fmt.Sprintf("El usuario '%s' no está habilitado", usuario.Nombre))
+	}
+	if !libro.EsPrestable() {
+		decision.Permitido = false
+		decision.Motivos = append(decision.Motivos, fmt.Sprintf("El libro '%s' no está disponible", libro.Titulo))
+	}
+	if maxPrestamos > 0 {
+		if activos := b.prestamosActivosDe(usuario.ID); activos >= maxPrestamos {
+			decision.Permitido = false
+			decision.Motivos = append(decision.Motivos,
+				fmt.Sprintf("El usuario ya tiene %d préstamos activos (máximo %d)", activos, maxPrestamos))
+		}
+	}
+	return decision
+}
+
+// Explicar describe la decisión en texto legible
+func (d Decision) Explicar() string {
+	var sb strings.Builder
+	if d.Permitido {
+		fmt.Fprintf(&sb, "Préstamo permitido por %d días, hasta %d renovaciones, multa de %.2f por día",
+			d.DiasPrestamo, d.MaxRenovaciones, d.MultaDiaria)
+	} else {
+		sb.WriteString("Préstamo denegado:")
+		for _, motivo := range d.Motivos {
+			sb.WriteString("\n - " + motivo)
+		}
+	}
+	if len(d.ReglasAplicadas) > 0 {
+		sb.WriteString("\nReglas aplicadas: " + strings.Join(d.ReglasAplicadas, ", "))
+	}
+	return sb.String()
+}
+
+// ExplicarPrestamo evalúa un préstamo sin realizarlo
+func (b Biblioteca) ExplicarPrestamo(libroID, usuarioID int) (Decision, error) {
+	libro := b.BuscarLibro(libroID)
+	if libro == nil {
+		return Decision{}, errLibroNoEncontrado(libroID)
+	}
+	usuario := b.BuscarUsuario(usuarioID)
+	if usuario == nil {
+		return Decision{}, errUsuarioNoEncontrado(usuarioID)
+	}
+	return b.motorPoliticas().Evaluar(b, *usuario, *libro), nil
+}
+
+// RenovarPrestamo extiende el préstamo activo de un libro si la
+// política lo permite y no está vencido
+func (b *Biblioteca) RenovarPrestamo(libroID int) error {
+	prestamo := b.buscarPrestamoActivo(libroID)
+	if prestamo == nil {
+		return nuevoError(CodigoPrestamoNoEncontrado, "libro", libroID, "", "",
+			"No existe un prestamo activo para el libro '%d'", libroID)
+	}
+	ahora := b.ahora()
+	if ahora.After(prestamo.FechaDevolucion) {
+		return nuevoError(CodigoOperacionInvalida, "prestamo", prestamo.ID, "fecha_devolucion",
+			prestamo.FechaDevolucion.Format(formatoFecha), "El préstamo '%d' está vencido", prestamo.ID)
+	}
+	if prestamo.Renovaciones >= prestamo.MaxRenovaciones {
+		return nuevoError(CodigoOperacionInvalida, "prestamo", prestamo.ID, "renovaciones",
+			fmt.Sprint(prestamo.Renovaciones), "El préstamo '%d' ya alcanzó el máximo de renovaciones", prestamo.ID)
+	}
+	prestamo.Renovaciones++
+	prestamo.FechaDevolucion = b.calcularVencimiento(ahora, prestamo.DiasPrestamo)
+	b.publicar(Evento{Tipo: EventoLibroRenovado, LibroID: libroID,
+		UsuarioID: prestamo.UsuarioID, PrestamoID: prestamo.ID})
+	return nil
+}
+
+// AsignarCategoriaUsuario clasifica al usuario ("estudiante", "docente"...)
+// para que le apliquen las reglas de préstamo de su categoría
+func (b *Biblioteca) AsignarCategoriaUsuario(usuarioID int, categoria string) error {
+	usuario := b.BuscarUsuario(usuarioID)
+	if usuario == nil {
+		return errUsuarioNoEncontrado(usuarioID)
+	}
+	usuario.Categoria = strings.ToLower(strings.TrimSpace(categoria))
+	return nil
+}
+
+// motorPoliticas retorna el motor configurado o uno sin reglas
+func (b Biblioteca) motorPoliticas() *MotorPoliticas {
+	if b.Politicas != nil {
+		return b.Politicas
+	}
+	return NuevoMotorPoliticas(Politica{})
+}
+
+func (b Biblioteca) prestamosActivosDe(usuarioID int) int {
+	activos := 0
+	for _, prestamo := range b.Prestamos {
+		if prestamo.UsuarioID == usuarioID && !prestamo.Devuelto {
+			activos++
+		}
+	}
+	return activos
+}
+
+func (r Regla) coincide(b Biblioteca, usuario Usuario, libro Libro) bool {
+	if len(r.CategoriasUsuario) > 0 && !contieneSinMayusculas(r.CategoriasUsuario, usuario.Categoria) {
+		return false
+	}
+	if len(r.CategoriasLibro) > 0 && !contieneSinMayusculas(r.CategoriasLibro, libro.Categoria) {
+		return false
+	}
+	if r.Tamano == "grande" && !libro.EsGrande() || r.Tamano == "normal" && libro.EsGrande() {
+		return false
+	}
+	if len(r.Sedes) > 0 && !contieneSinMayusculas(r.Sedes, b.Nombre) {
+		return false
+	}
+	return true
+}
+
+func contieneSinMayusculas(lista []string, valor string) bool {
+	for _, elemento := range lista {
+		if strings.EqualFold(elemento, valor) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+// bibliotecaConPoliticas carga politicas.json en una biblioteca con reloj fijo
+func bibliotecaConPoliticas(t *testing.T, sede string) (*Biblioteca, *RelojFalso) {
+	t.Helper()
+	motor, err := CargarPoliticas(rutaPoliticas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := NuevaBiblioteca(sede, "")
+	b.Politicas = motor
+	reloj := NuevoRelojFalso(time.Date(2026, time.March, 2, 10, 0, 0, 0, time.UTC))
+	b.Reloj = reloj
+	return b, reloj
+}
+
+func TestPoliticasEvaluar(t *testing.T) {
+	casos := []struct {
+		nombre    string
+		sede      string
+		usuario   string // categoría del usuario
+		libro     string // categoría del libro
+		paginas   int
+		permitido bool
+		dias      int
+		renov     int
+		reglas    []string
+		motivo    string
+	}{
+		{"estudiante con libro normal", "Biblioteca Central", "estudiante", "novela", 200,
+			true, 14, 1, nil, ""},
+		{"estudiante con libro extenso", "Biblioteca Central", "estudiante", "novela", 900,
+			true, 21, 1, []string{"Libros extensos"}, ""},
+		// La regla de docentes va después: un docente nunca recibe menos días por un libro extenso
+		{"docente con libro extenso", "Biblioteca Central", "docente", "novela", 900,
+			true, 30, 3, []string{"Libros extensos", "Docentes"}, ""},
+		{"estudiante con obra de referencia", "Biblioteca Central", "estudiante", "referencia", 200,
+			false, 14, 1, []string{"Obras de referencia"},
+			"Regla 'Obras de referencia': las obras de referencia se consultan en sala"},
+		{"docente con referencia en la sede central", "Biblioteca Central", "docente", "referencia", 200,
+			true, 3, 0, []string{"Docentes", "Obras de referencia", "Referencia para docentes en la sede central"}, ""},
+		{"docente con referencia en otra sede", "Biblioteca Norte", "docente", "Diccionario", 200,
+			false, 30, 3, []string{"Docentes", "Obras de referencia"},
+			"Regla 'Obras de referencia': las obras de referencia se consultan en sala"},
+	}
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			b, _ := bibliotecaConPoliticas(t, caso.sede)
+			usuario := Usuario{ID: 1, Nombre: "Ana", Email: "ana@gmail.com", Activo: true, Categoria: caso.usuario}
+			libro := Libro{ID: 2, Titulo: "Libro", Paginas: caso.paginas, Estado: EnCirculacion, Categoria: caso.libro}
+
+			decision := b.Politicas.Evaluar(*b, usuario, libro)
+			if decision.Permitido != caso.permitido || decision.DiasPrestamo != caso.dias ||
+				decision.MaxRenovaciones != caso.renov {
+				t.Errorf("decisión %+v; se esperaba permitido %v, %d días y %d renovaciones",
+					decision, caso.permitido, caso.dias, caso.renov)
+			}
+			if strings.Join(decision.ReglasAplicadas, ",") != strings.Join(caso.reglas, ",") {
+				t.Errorf("reglas %v, se esperaban %v", decision.ReglasAplicadas, caso.reglas)
+			}
+			if caso.motivo == "" && len(decision.Motivos) > 0 || caso.motivo != "" &&
+				(len(decision.Motivos) != 1 || decision.Motivos[0] != caso.motivo) {
+				t.Errorf("motivos %q, se esperaba %q", decision.Motivos, caso.motivo)
+			}
+		})
+	}
+}
+
+func TestPoliticasMaxPrestamos(t *testing.T) {
+	b, _ := bibliotecaConPoliticas(t, "Biblioteca Central")
+	usuario, _ := b.RegistrarUsuario("Ana", "ana@gmail.com", "")
+	var libros []int
+	for _, titulo := range []string{"Uno", "Dos", "Tres", "Cuatro"} {
+		libro, _ := b.AgregarLibro(titulo, "Autor", "", 100)
+		libros = append(libros, libro.ID)
+	}
+	for _, libroID := range libros[:3] {
+		if err := b.PrestarLibro(libroID, usuario.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := b.PrestarLibro(libros[3], usuario.ID)
+	var errBiblioteca *ErrorBiblioteca
+	if !errors.As(err, &errBiblioteca) || errBiblioteca.Codigo != CodigoPrestamoDenegado {
+		t.Fatalf("el cuarto préstamo debía denegarse: %v", err)
+	}
+	if !strings.Contains(err.Error(), "El usuario ya tiene 3 préstamos activos (máximo 3)") {
+		t.Errorf("motivo inesperado: %v", err)
+	}
+
+	// Los docentes pueden llevar hasta 10
+	if err := b.AsignarCategoriaUsuario(usuario.ID, " Docente "); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.PrestarLibro(libros[3], usuario.ID); err != nil {
+		t.Errorf("un docente debía poder llevar el cuarto libro: %v", err)
+	}
+}
+
+func TestDecisionExplicar(t *testing.T) {
+	casos := []struct {
+		decision Decision
+		texto    string
+	}{
+		{Decision{Permitido: true, DiasPrestamo: 21, MaxRenovaciones: 1, MultaDiaria: 0.5,
+			ReglasAplicadas: []string{"Libros extensos"}},
+			"Préstamo permitido por 21 días, hasta 1 renovaciones, multa de 0.50 por día\n" +
+				"Reglas aplicadas: Libros extensos"},
+		{Decision{Permitido: true, DiasPrestamo: 14, MultaDiaria: 1},
+			"Préstamo permitido por 14 días, hasta 0 renovaciones, multa de 1.00 por día"},
+		{Decision{Permitido: false, Motivos: []string{"Regla 'X': no", "El libro 'Y' no está disponible"},
+			ReglasAplicadas: []string{"X"}},
+			"Préstamo denegado:\n - Regla 'X': no\n - El libro 'Y' no está disponible\nReglas aplicadas: X"},
+	}
+	for _, caso := range casos {
+		if got := caso.decision.Explicar(); got != caso.texto {
+			t.Errorf("Explicar:\n%s\nse esperaba:\n%s", got, caso.texto)
+		}
+	}
+}
+
+func TestPoliticaValidar(t *testing.T) {
+	casos := []struct {
+		nombre   string
+		politica Politica
+		error    string
+	}{
+		{"válida", Politica{Predeterminada: Condiciones{DiasPrestamo: ptr(14)},
+			Reglas: []Regla{{Nombre: "Extensos", Tamano: "grande", Condiciones: Condiciones{DiasPrestamo: ptr(21)}}}}, ""},
+		{"días en cero", Politica{Predeterminada: Condiciones{DiasPrestamo: ptr(0)}}, "dias_prestamo no válido"},
+		{"renovaciones negativas", Politica{Reglas: []Regla{{Nombre: "R", Condiciones: Condiciones{MaxRenovaciones: ptr(-1)}}}},
+			"La regla 'R' tiene max_renovaciones negativo"},
+		{"multa negativa", Politica{Predeterminada: Condiciones{MultaDiaria: ptr(-0.5)}}, "multa_diaria negativa"},
+		{"máximo de préstamos negativo", Politica{Reglas: []Regla{{Nombre: "R", Condiciones: Condiciones{MaxPrestamos: ptr(-2)}}}},
+			"max_prestamos negativo"},
+		{"regla sin nombre", Politica{Reglas: []Regla{{}}}, "La regla 1 no tiene nombre"},
+		{"tamaño desconocido", Politica{Reglas: []Regla{{Nombre: "R", Tamano: "mediano"}}}, "tamaño no válido"},
+	}
+	for _, caso := range casos {
+		err := caso.politica.Validar()
+		if caso.error == "" && err != nil || caso.error != "" && (err == nil || !strings.Contains(err.Error(), caso.error)) {
+			t.Errorf("%s: error %v, se esperaba %q", caso.nombre, err, caso.error)
+		}
+	}
+}
+
+func TestRenovarPrestamo(t *testing.T) {
+	b, reloj := bibliotecaConPoliticas(t, "Biblioteca Central")
+	usuario, _ := b.RegistrarUsuario("Ana", "ana@gmail.com", "")
+	libro, _ := b.AgregarLibro("Rayuela", "Julio Cortázar", "", 200)
+	if err := b.PrestarLibro(libro.ID, usuario.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	reloj.Avanzar(5 * 24 * time.Hour)
+	if err := b.RenovarPrestamo(libro.ID); err != nil {
+		t.Fatalf("la primera renovación debía aceptarse: %v", err)
+	}
+	prestamo := b.buscarPrestamoActivo(libro.ID)
+	if esperado := b.calcularVencimiento(reloj.Ahora(), 14); !prestamo.FechaDevolucion.Equal(esperado) ||
+		prestamo.Renovaciones != 1 {
+		t.Errorf("préstamo renovado %+v; se esperaba vencer el %v", prestamo, esperado)
+	}
+
+	casos := []struct {
+		nombre  string
+		libroID int
+		avanzar time.Duration
+		codigo  CodigoError
+		mensaje string
+	}{
+		{"supera el máximo de renovaciones", libro.ID, 0, CodigoOperacionInvalida, "ya alcanzó el máximo de renovaciones"},
+		{"préstamo vencido", libro.ID, 60 * 24 * time.Hour, CodigoOperacionInvalida, "está vencido"},
+		{"sin préstamo activo", 999, 0, CodigoPrestamoNoEncontrado, ""},
+	}
+	for _, caso := range casos {
+		reloj.Avanzar(caso.avanzar)
+		err := b.RenovarPrestamo(caso.libroID)
+		var errBiblioteca *ErrorBiblioteca
+		if !errors.As(err, &errBiblioteca) || errBiblioteca.Codigo != caso.codigo ||
+			!strings.Contains(err.Error(), caso.mensaje) {
+			t.Errorf("%s: error %v, se esperaba el código %s", caso.nombre, err, caso.codigo)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ==========================================
+// PRIVACIDAD: EXPORTACIÓN Y BORRADO DE DATOS PERSONALES
+// ==========================================
+
+// ExportacionUsuario contiene todos los datos personales de un usuario
+type ExportacionUsuario struct {
+	GeneradoEn time.Time           `json:"generado_en"`
+	Usuario    DatosPersonales     `json:"usuario"`
+	Prestamos  []PrestamoExportado `json:"prestamos"`
+	Multas     []MultaExportada    `json:"multas"`
+}
+
+// DatosPersonales son los datos de contacto guardados del usuario
+type DatosPersonales struct {
+	ID          int    `json:"id"`
+	Nombre      string `json:"nombre"`
+	Email       string `json:"email"`
+	Telefono    string `json:"telefono"`
+	Activo      bool   `json:"activo"`
+	Idioma      Idioma `json:"idioma,omitempty"`
+	Anonimizado bool   `json:"anonimizado"`
+}
+
+// PrestamoExportado es un préstamo con el título y autor del libro
+type PrestamoExportado struct {
+	ID              int       `json:"id"`
+	LibroID         int       `json:"libro_id"`
+	Titulo          string    `json:"titulo"`
+	Autor           string    `json:"autor"`
+	FechaPrestamo   time.Time `json:"fecha_prestamo"`
+	FechaDevolucion time.Time `json:"fecha_devolucion"`
+	Devuelto        bool      `json:"devuelto"`
+	Perdido         bool      `json:"perdido"`
+}
+
+// MultaExportada es una multa del usuario, pagada o pendiente
+type MultaExportada struct {
+	ID         int       `json:"id"`
+	PrestamoID int       `json:"prestamo_id"`
+	Motivo     string    `json:"motivo"`
+	Monto      float64   `json:"monto"`
+	Fecha      time.Time `json:"fecha"`
+	Pagada     bool      `json:"pagada"`
+}
+
+// ExportarDatosUsuario retorna en JSON los datos personales del usuario
+// junto con su historial de préstamos y multas
+func (b Biblioteca) ExportarDatosUsuario(usuarioID int) ([]byte, error) {
+	usuario := b.BuscarUsuario(usuarioID)
+	if usuario == nil {
+		return nil, errUsuarioNoEncontrado(usuarioID)
+	}
+
+	exportacion := ExportacionUsuario{
+		GeneradoEn: b.ahora(),
+		Usuario: DatosPersonales{
+			ID:          usuario.ID,
+			Nombre:      usuario.Nombre,
+			Email:       usuario.Email,
+			Telefono:    usuario.Telefono,
+			Activo:      usuario.Activo,
+			Idioma:      usuario.Idioma,
+			Anonimizado: usuario.Anonimizado,
+		},
+		Prestamos: make([]PrestamoExportado, 0),
+		Multas:    make([]MultaExportada, 0),
+	}
+
+	for _, prestamo := range b.Prestamos {
+		if prestamo.UsuarioID != usuarioID {
+			continue
+		}
+		exportado := PrestamoExportado{
+			ID:              prestamo.ID,
+			LibroID:         prestamo.LibroID,
+			FechaPrestamo:   prestamo.FechaPrestamo,
+			FechaDevolucion: prestamo.FechaDevolucion,
+			Devuelto:        prestamo.Devuelto,
+			Perdido:         prestamo.Perdido,
+		}
+		if libro := b.BuscarLibro(prestamo.LibroID); libro != nil {
+			exportado.Titulo = libro.Titulo
+			exportado.Autor = libro.Autor
+		}
+		exportacion.Prestamos = append(exportacion.Prestamos, exportado)
+	}
+
+	for _, multa := range b.Multas {
+		if multa.UsuarioID != usuarioID {
+			continue
+		}
+		exportacion.Multas = append(exportacion.Multas, MultaExportada{
+			ID:         multa.ID,
+			PrestamoID: multa.PrestamoID,
+			Motivo:     multa.Motivo,
+			Monto:      multa.Monto,
+			Fecha:      multa.Fecha,
+			Pagada:     multa.Pagada,
+		})
+	}
+
+	return json.MarshalIndent(exportacion, "", "  ")
+}
+
+// AnonimizarUsuario borra los datos personales del usuario. Sus préstamos
+// y multas se conservan (con el mismo UsuarioID) para que las
+// estadísticas sigan cuadrando. No se permite mientras tenga préstamos
+// activos o multas pendientes.
+func (b *Biblioteca) AnonimizarUsuario(usuarioID int) error {
+	usuario := b.BuscarUsuario(usuarioID)
+	if usuario == nil {
+		return errUsuarioNoEncontrado(usuarioID)
+	}
+	if usuario.Anonimizado {
+		return nuevoError(CodigoOperacionInvalida, "usuario", usuarioID, "anonimizado", "true",
+			"El usuario '%d' ya fue anonimizado", usuarioID)
+	}
+
+	for _, prestamo := range b.Prestamos {
+		if prestamo.UsuarioID == usuarioID && !prestamo.Devuelto {
+			return nuevoError(CodigoOperacionInvalida, "usuario", usuarioID, "prestamos", "activos",
+				"El usuario '%s' tiene préstamos activos", usuario.Nombre)
+		}
+	}
+	if pendientes := b.MultasPendientes(usuarioID); len(pendientes) > 0 {
+		return nuevoError(CodigoOperacionInvalida, "usuario", usuarioID, "multas", "pendientes",
+			"El usuario '%s' tiene %d multas pendientes", usuario.Nombre, len(pendientes))
+	}
+
+	borrarDatosPersonales(usuario)
+	b.anonimizados = append(b.anonimizados, usuarioID)
+	return nil
+}
+
+// borrarDatosPersonales deja al usuario sin datos que lo identifiquen
+func borrarDatosPersonales(usuario *Usuario) {
+	usuario.Nombre = fmt.Sprintf("Usuario anonimizado %d", usuario.ID)
+	usuario.Email = ""
+	usuario.Telefono = ""
+	usuario.Idioma = ""
+	usuario.Activo = false
+	usuario.Anonimizado = true
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ==========================================
+// RECOMENDACIONES A PARTIR DEL HISTORIAL
+// ==========================================
+
+// Pesos de cada señal al puntuar un libro candidato
+const (
+	pesoCoPrestamo  = 3.0
+	pesoAutor       = 2.0
+	pesoCategoria   = 1.0
+	pesoPopularidad = 0.1
+)
+
+// Recomendacion es un libro sugerido con su puntaje y el motivo principal
+type Recomendacion struct {
+	Libro   Libro
+	Puntaje float64
+	Motivo  string
+}
+
+// AsignarCategoria clasifica un libro (por ejemplo "novela" o "programación")
+func (b *Biblioteca) AsignarCategoria(libroID int, categoria string) error {
+	libro := b.BuscarLibro(libroID)
+	if libro == nil {
+		return errLibroNoEncontrado(libroID)
+	}
+	libro.Categoria = strings.TrimSpace(categoria)
+	return nil
+}
+
+// Recomendar sugiere hasta n títulos para el usuario combinando:
+//   - co-préstamos: quienes leyeron lo mismo que él también leyeron X
+//   - autores y categorías que ya leyó
+//   - popularidad general, como respaldo para usuarios sin historial
+//
+// Los ejemplares de un mismo título (título y autor normalizados) cuentan
+// como uno solo: nunca se sugiere un título que el usuario ya pidió
+// prestado, aunque sea en otro ejemplar, y cada título aparece una vez.
+func (b Biblioteca) Recomendar(usuarioID, n int) ([]Recomendacion, error) {
+	if b.BuscarUsuario(usuarioID) == nil {
+		return nil, errUsuarioNoEncontrado(usuarioID)
+	}
+	if n <= 0 {
+		return []Recomendacion{}, nil
+	}
+
+	titulos := make(map[int]string, len(b.Libros))
+	for _, libro := range b.Libros {
+		titulos[libro.ID] = claveTitulo(libro)
+	}
+
+	// Historial: títulos leídos por cada usuario y préstamos por título
+	leidosPor := make(map[int]map[string]bool)
+	popularidad := make(map[string]int)
+	for _, prestamo := range b.Prestamos {
+		titulo, ok := titulos[prestamo.LibroID]
+		if !ok {
+			continue
+		}
+		if leidosPor[prestamo.UsuarioID] == nil {
+			leidosPor[prestamo.UsuarioID] = make(map[string]bool)
+		}
+		leidosPor[prestamo.UsuarioID][titulo] = true
+		popularidad[titulo]++
+	}
+	propios := leidosPor[usuarioID]
+
+	autores := make(map[string]bool)
+	categorias := make(map[string]bool)
+	for _, libro := range b.Libros {
+		if !propios[titulos[libro.ID]] {
+			continue
+		}
+		autores[strings.ToLower(libro.Autor)] = true
+		if libro.Categoria != "" {
+			categorias[strings.ToLower(libro.Categoria)] = true
+		}
+	}
+
+	// Co-préstamos ponderados por cuánto se parece el otro lector
+	coPrestamos := make(map[string]float64)
+	for otroID, leidos := range leidosPor {
+		if otroID == usuarioID {
+			continue
+		}
+		enComun := 0
+		for titulo := range leidos {
+			if propios[titulo] {
+				enComun++
+			}
+		}
+		if enComun == 0 {
+			continue
+		}
+		similitud := float64(enComun) / float64(len(leidos))
+		for titulo := range leidos {
+			if !propios[titulo] {
+				coPrestamos[titulo] += similitud
+			}
+		}
+	}
+
+	candidatos := make([]Recomendacion, 0)
+	porTitulo := make(map[string]int) // título → posición en candidatos
+	for _, libro := range b.Libros {
+		titulo := titulos[libro.ID]
+		if propios[titulo] || libro.Estado == DadoDeBaja || libro.Estado == Perdido {
+			continue
+		}
+		// De varios ejemplares se sugiere el primero que esté en el estante
+		if i, visto := porTitulo[titulo]; visto {
+			if candidatos[i].Libro.Prestado && !libro.Prestado {
+				candidatos[i].Libro = libro
+			}
+			continue
+		}
+		puntajeCo := pesoCoPrestamo * coPrestamos[titulo]
+		puntajeAutor, puntajeCategoria := 0.0, 0.0
+		if autores[strings.ToLower(libro.Autor)] {
+			puntajeAutor = pesoAutor
+		}
+		if libro.Categoria != "" && categorias[strings.ToLower(libro.Categoria)] {
+			puntajeCategoria = pesoCategoria
+		}
+		puntajePopularidad := pesoPopularidad * float64(popularidad[titulo])
+
+		motivo := "Disponible en el catálogo"
+		switch {
+		case puntajeCo > 0 && puntajeCo >= puntajeAutor && puntajeCo >= puntajeCategoria:
+			motivo = "Lectores con gustos similares también lo leyeron"
+		case puntajeAutor > 0 && puntajeAutor >= puntajeCategoria:
+			motivo = fmt.Sprintf("Otro libro de %s", libro.Autor)
+		case puntajeCategoria > 0:
+			motivo = fmt.Sprintf("Más de la categoría %s", libro.Categoria)
+		case puntajePopularidad > 0:
+			motivo = "Popular en la biblioteca"
+		}
+
+		porTitulo[titulo] = len(candidatos)
+		candidatos = append(candidatos, Recomendacion{
+			Libro:   libro,
+			Puntaje: puntajeCo + puntajeAutor + puntajeCategoria + puntajePopularidad,
+			Motivo:  motivo,
+		})
+	}
+
+	sort.SliceStable(candidatos, func(i, j int) bool {
+		if candidatos[i].Puntaje != candidatos[j].Puntaje {
+			return candidatos[i].Puntaje > candidatos[j].Puntaje
+		}
+		return candidatos[i].Libro.ID < candidatos[j].Libro.ID
+	})
+	if len(candidatos) > n {
+		candidatos = candidatos[:n]
+	}
+	return candidatos, nil
+}
+
+// claveTitulo identifica un título sin importar el ejemplar
+func claveTitulo(libro Libro) string {
+	return normalizarTexto(libro.Titulo) + "|" + normalizarTexto(libro.Autor)
+}
@@ -0,0 +1,62 @@
+package main
+
+import "testing"
+
+func TestRecomendarPorTitulo(t *testing.T) {
+	b := NuevaBiblioteca("Biblioteca de Pruebas", "")
+	agregar := func(titulo, autor string) int {
+		libro, err := b.AgregarLibro(titulo, autor, "", 300)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return libro.ID
+	}
+	quijote1 := agregar("El Quijote", "Miguel de Cervantes")
+	quijote2 := agregar("el quijote", "Miguel de  Cervantes") // otro ejemplar
+	novelas1 := agregar("Novelas ejemplares", "Miguel de Cervantes")
+	novelas2 := agregar("Novelas Ejemplares", "Miguel de Cervantes")
+	agregar("Novelas ejemplares.", "Miguel de Cervantes")
+	rayuela := agregar("Rayuela", "Julio Cortázar")
+
+	ana, _ := b.RegistrarUsuario("Ana", "ana@gmail.com", "")
+	luis, _ := b.RegistrarUsuario("Luis", "luis@gmail.com", "")
+	prestarYDevolver := func(libroID, usuarioID int) {
+		if err := b.PrestarLibro(libroID, usuarioID); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.DevolverLibro(libroID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	prestarYDevolver(quijote1, ana.ID)
+	prestarYDevolver(quijote2, luis.ID)
+	prestarYDevolver(novelas1, luis.ID)
+	// El primer ejemplar de Novelas ejemplares queda prestado
+	if err := b.PrestarLibro(novelas1, luis.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	recomendaciones, err := b.Recomendar(ana.ID, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recomendaciones) != 2 {
+		t.Fatalf("se esperaban 2 títulos y llegaron %d: %+v", len(recomendaciones), recomendaciones)
+	}
+	for _, r := range recomendaciones {
+		if r.Libro.ID == quijote1 || r.Libro.ID == quijote2 {
+			t.Errorf("se recomendó otro ejemplar de un título ya leído: %+v", r.Libro)
+		}
+	}
+	primera := recomendaciones[0]
+	if primera.Libro.ID != novelas2 {
+		t.Errorf("primera recomendación %d (%s), se esperaba el ejemplar disponible %d",
+			primera.Libro.ID, primera.Libro.Titulo, novelas2)
+	}
+	if primera.Motivo != "Otro libro de Miguel de Cervantes" {
+		t.Errorf("motivo %q", primera.Motivo)
+	}
+	if recomendaciones[1].Libro.ID != rayuela {
+		t.Errorf("segunda recomendación %d, se esperaba %d", recomendaciones[1].Libro.ID, rayuela)
+	}
+}
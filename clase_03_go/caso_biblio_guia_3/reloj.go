@@ -0,0 +1,46 @@
+package main
+
+import "time"
+
+// ==========================================
+// RELOJ: FECHA ACTUAL REEMPLAZABLE EN PRUEBAS
+// ==========================================
+
+// Reloj entrega la fecha y hora actual
+type Reloj interface {
+	Ahora() time.Time
+}
+
+// RelojFalso es un reloj controlado a mano, útil para escenarios y
+// pruebas donde el tiempo debe avanzar de forma predecible
+type RelojFalso struct {
+	actual time.Time
+}
+
+// NuevoRelojFalso crea un reloj detenido en la fecha indicada
+func NuevoRelojFalso(inicio time.Time) *RelojFalso {
+	return &RelojFalso{actual: inicio}
+}
+
+// Ahora implementa Reloj
+func (r *RelojFalso) Ahora() time.Time {
+	return r.actual
+}
+
+// Avanzar mueve el reloj hacia adelante
+func (r *RelojFalso) Avanzar(d time.Duration) {
+	r.actual = r.actual.Add(d)
+}
+
+// Fijar pone el reloj en una fecha concreta
+func (r *RelojFalso) Fijar(fecha time.Time) {
+	r.actual = fecha
+}
+
+// ahora retorna la hora del reloj de la biblioteca o la del sistema
+func (b Biblioteca) ahora() time.Time {
+	if b.Reloj != nil {
+		return b.Reloj.Ahora()
+	}
+	return time.Now()
+}
@@ -0,0 +1,437 @@
+package main
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ==========================================
+// RESPALDOS, RESTAURACIÓN Y CONSISTENCIA
+// ==========================================
+
+// versionInstantanea cambia si cambia el formato de los respaldos
+const versionInstantanea = 1
+
+// formatoNombreRespaldo ordena los archivos cronológicamente por nombre
+const formatoNombreRespaldo = "20060102T150405Z"
+
+// Instantanea es el estado completo de la biblioteca en un momento dado.
+// La configuración (reloj, calendario, políticas, suscriptores) no se
+// respalda: la conserva la biblioteca donde se restaura.
+type Instantanea struct {
+	Version     int        `json:"version"`
+	Fecha       time.Time  `json:"fecha"`
+	Nombre      string     `json:"nombre"`
+	Direccion   string     `json:"direccion"`
+	Libros      []Libro    `json:"libros"`
+	Usuarios    []Usuario  `json:"usuarios"`
+	Prestamos   []Prestamo `json:"prestamos"`
+	Multas      []Multa    `json:"multas"`
+	MultaDiaria float64    `json:"multa_diaria"`
+	Idioma      Idioma     `json:"idioma"`
+	ProximoID   int        `json:"proximo_id"`
+	// Anonimizados son los usuarios borrados hasta la fecha del respaldo
+	Anonimizados []int `json:"anonimizados,omitempty"`
+}
+
+// Respaldo describe un archivo de respaldo en disco
+type Respaldo struct {
+	Nombre string
+	Ruta   string
+	Fecha  time.Time
+	Tamano int64
+}
+
+// Inconsistencia es un invariante que no se cumple
+type Inconsistencia struct {
+	Entidad string
+	ID      int
+	Detalle string
+}
+
+func (i Inconsistencia) String() string {
+	return fmt.Sprintf("%s %d: %s", i.Entidad, i.ID, i.Detalle)
+}
+
+// GestorRespaldos guarda respaldos comprimidos en un directorio y
+// conserva solo los más recientes
+type GestorRespaldos struct {
+	Directorio string
+	Retener    int // cantidad de respaldos a conservar; 0: todos
+}
+
+// NuevoGestorRespaldos crea el directorio si no existe
+func NuevoGestorRespaldos(directorio string, retener int) (*GestorRespaldos, error) {
+	if err := os.MkdirAll(directorio, 0o755); err != nil {
+		return nil, fmt.Errorf("No se pudo crear el directorio de respaldos: %w", err)
+	}
+	return &GestorRespaldos{Directorio: directorio, Retener: retener}, nil
+}
+
+// Instantanea copia el estado actual de la biblioteca
+func (b Biblioteca) Instantanea() Instantanea {
+	return Instantanea{
+		Version:      versionInstantanea,
+		Fecha:        b.ahora(),
+		Nombre:       b.Nombre,
+		Direccion:    b.Direccion,
+		Libros:       append([]Libro(nil), b.Libros...),
+		Usuarios:     append([]Usuario(nil), b.Usuarios...),
+		Prestamos:    append([]Prestamo(nil), b.Prestamos...),
+		Multas:       append([]Multa(nil), b.Multas...),
+		MultaDiaria:  b.MultaDiaria,
+		Idioma:       b.Idioma,
+		ProximoID:    b.proximoID,
+		Anonimizados: append([]int(nil), b.anonimizados...),
+	}
+}
+
+// Respaldar escribe un respaldo comprimido con su suma SHA-256 al lado,
+// en el formato de sha256sum, y luego descarta los respaldos sobrantes
+func (g *GestorRespaldos) Respaldar(b *Biblioteca) (Respaldo, error) {
+	instantanea := b.Instantanea()
+	nombre := g.nombreLibre(instantanea.Fecha)
+	ruta := filepath.Join(g.Directorio, nombre)
+
+	// Se escribe a un temporal y se renombra para no dejar respaldos a medias
+	temporal, err := os.CreateTemp(g.Directorio, ".respaldo-*")
+	if err != nil {
+		return Respaldo{}, fmt.Errorf("No se pudo crear el respaldo: %w", err)
+	}
+	defer os.Remove(temporal.Name())
+
+	suma := sha256.New()
+	comprimido := gzip.NewWriter(io.MultiWriter(temporal, suma))
+	comprimido.Name = strings.TrimSuffix(nombre, ".gz")
+	comprimido.ModTime = instantanea.Fecha
+	if err := json.NewEncoder(comprimido).Encode(instantanea); err != nil {
+		temporal.Close()
+		return Respaldo{}, fmt.Errorf("No se pudo escribir el respaldo: %w", err)
+	}
+	if err := comprimido.Close(); err != nil {
+		temporal.Close()
+		return Respaldo{}, fmt.Errorf("No se pudo comprimir el respaldo: %w", err)
+	}
+	if err := temporal.Close(); err != nil {
+		return Respaldo{}, fmt.Errorf("No se pudo cerrar el respaldo: %w", err)
+	}
+	if err := os.Rename(temporal.Name(), ruta); err != nil {
+		return Respaldo{}, fmt.Errorf("No se pudo guardar el respaldo: %w", err)
+	}
+
+	linea := fmt.Sprintf("%s  %s\n", hex.EncodeToString(suma.Sum(nil)), nombre)
+	if err := os.WriteFile(ruta+".sha256", []byte(linea), 0o644); err != nil {
+		return Respaldo{}, fmt.Errorf("No se pudo guardar la suma de verificación: %w", err)
+	}
+
+	info, err := os.Stat(ruta)
+	if err != nil {
+		return Respaldo{}, err
+	}
+	if err := g.Rotar(); err != nil {
+		return Respaldo{}, err
+	}
+	return Respaldo{Nombre: nombre, Ruta: ruta, Fecha: instantanea.Fecha, Tamano: info.Size()}, nil
+}
+
+// Listar retorna los respaldos del directorio, del más antiguo al más nuevo
+func (g *GestorRespaldos) Listar() ([]Respaldo, error) {
+	entradas, err := os.ReadDir(g.Directorio)
+	if err != nil {
+		return nil, fmt.Errorf("No se pudo leer el directorio de respaldos: %w", err)
+	}
+	respaldos := make([]Respaldo, 0)
+	for _, entrada := range entradas {
+		fecha, ok := fechaDeRespaldo(entrada.Name())
+		if !ok {
+			continue
+		}
+		info, err := entrada.Info()
+		if err != nil {
+			continue
+		}
+		respaldos = append(respaldos, Respaldo{
+			Nombre: entrada.Name(),
+			Ruta:   filepath.Join(g.Directorio, entrada.Name()),
+			Fecha:  fecha,
+			Tamano: info.Size(),
+		})
+	}
+	// En el mismo segundo, el sufijo -01, -02... va después del nombre base
+	sort.Slice(respaldos, func(i, j int) bool {
+		if !respaldos[i].Fecha.Equal(respaldos[j].Fecha) {
+			return respaldos[i].Fecha.Before(respaldos[j].Fecha)
+		}
+		if len(respaldos[i].Nombre) != len(respaldos[j].Nombre) {
+			return len(respaldos[i].Nombre) < len(respaldos[j].Nombre)
+		}
+		return respaldos[i].Nombre < respaldos[j].Nombre
+	})
+	return respaldos, nil
+}
+
+// Rotar borra los respaldos más antiguos que excedan Retener
+func (g *GestorRespaldos) Rotar() error {
+	if g.Retener <= 0 {
+		return nil
+	}
+	respaldos, err := g.Listar()
+	if err != nil {
+		return err
+	}
+	for len(respaldos) > g.Retener {
+		if err := os.Remove(respaldos[0].Ruta); err != nil {
+			return fmt.Errorf("No se pudo borrar el respaldo '%s': %w", respaldos[0].Nombre, err)
+		}
+		os.Remove(respaldos[0].Ruta + ".sha256")
+		respaldos = respaldos[1:]
+	}
+	return nil
+}
+
+// Verificar comprueba la suma SHA-256 del respaldo
+func (g *GestorRespaldos) Verificar(nombre string) error {
+	ruta := filepath.Join(g.Directorio, filepath.Base(nombre))
+	linea, err := os.ReadFile(ruta + ".sha256")
+	if err != nil {
+		return fmt.Errorf("No se encontró la suma de verificación de '%s': %w", nombre, err)
+	}
+	esperada, _, _ := strings.Cut(strings.TrimSpace(string(linea)), " ")
+
+	archivo, err := os.Open(ruta)
+	if err != nil {
+		return fmt.Errorf("No se pudo abrir el respaldo '%s': %w", nombre, err)
+	}
+	defer archivo.Close()
+	suma := sha256.New()
+	if _, err := io.Copy(suma, archivo); err != nil {
+		return fmt.Errorf("No se pudo leer el respaldo '%s': %w", nombre, err)
+	}
+	if hex.EncodeToString(suma.Sum(nil)) != esperada {
+		return fmt.Errorf("El respaldo '%s' está dañado: la suma no coincide", nombre)
+	}
+	return nil
+}
+
+// Leer verifica y descomprime un respaldo
+func (g *GestorRespaldos) Leer(nombre string) (Instantanea, error) {
+	if err := g.Verificar(nombre); err != nil {
+		return Instantanea{}, err
+	}
+	archivo, err := os.Open(filepath.Join(g.Directorio, filepath.Base(nombre)))
+	if err != nil {
+		return Instantanea{}, err
+	}
+	defer archivo.Close()
+	comprimido, err := gzip.NewReader(archivo)
+	if err != nil {
+		return Instantanea{}, fmt.Errorf("El respaldo '%s' no es un gzip válido: %w", nombre, err)
+	}
+	defer comprimido.Close()
+
+	var instantanea Instantanea
+	if err := json.NewDecoder(comprimido).Decode(&instantanea); err != nil {
+		return Instantanea{}, fmt.Errorf("El respaldo '%s' no se pudo leer: %w", nombre, err)
+	}
+	if instantanea.Version != versionInstantanea {
+		return Instantanea{}, fmt.Errorf("El respaldo '%s' tiene una versión no soportada (%d)", nombre, instantanea.Version)
+	}
+	return instantanea, nil
+}
+
+// Restaurar reemplaza los datos de la biblioteca por los del respaldo.
+// Si el respaldo no pasa la verificación de consistencia, la biblioteca
+// no se modifica y se retornan las inconsistencias encontradas.
+func (g *GestorRespaldos) Restaurar(b *Biblioteca, nombre string) ([]Inconsistencia, error) {
+	instantanea, err := g.Leer(nombre)
+	if err != nil {
+		return nil, err
+	}
+	restaurada := *b
+	restaurada.cargarInstantanea(instantanea)
+	if problemas := restaurada.VerificarConsistencia(); len(problemas) > 0 {
+		return problemas, fmt.Errorf("El respaldo '%s' tiene %d inconsistencias", nombre, len(problemas))
+	}
+	b.cargarInstantanea(instantanea)
+	return nil, nil
+}
+
+// RestaurarHasta restaura el último respaldo tomado en o antes de la fecha
+func (g *GestorRespaldos) RestaurarHasta(b *Biblioteca, fecha time.Time) (Respaldo, []Inconsistencia, error) {
+	respaldos, err := g.Listar()
+	if err != nil {
+		return Respaldo{}, nil, err
+	}
+	for i := len(respaldos) - 1; i >= 0; i-- {
+		if respaldos[i].Fecha.After(fecha) {
+			continue
+		}
+		problemas, err := g.Restaurar(b, respaldos[i].Nombre)
+		return respaldos[i], problemas, err
+	}
+	return Respaldo{}, nil, fmt.Errorf("No hay respaldos anteriores a %s", fecha.Format(time.RFC3339))
+}
+
+// Programar respalda la biblioteca cada intervalo hasta que se llame a la
+// función retornada. El mutex debe ser el mismo que protege la biblioteca
+// en el resto del programa; los errores se entregan a alError.
+func (g *GestorRespaldos) Programar(b *Biblioteca, mu sync.Locker, intervalo time.Duration, alError func(error)) (detener func()) {
+	fin := make(chan struct{})
+	listo := make(chan struct{})
+	go func() {
+		defer close(listo)
+		ticker := time.NewTicker(intervalo)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-fin:
+				return
+			case <-ticker.C:
+				mu.Lock()
+				_, err := g.Respaldar(b)
+				mu.Unlock()
+				if err != nil && alError != nil {
+					alError(err)
+				}
+			}
+		}
+	}()
+	var unaVez sync.Once
+	return func() {
+		unaVez.Do(func() { close(fin) })
+		<-listo
+	}
+}
+
+// VerificarConsistencia revisa los invariantes entre libros, usuarios,
+// préstamos y multas
+func (b Biblioteca) VerificarConsistencia() []Inconsistencia {
+	problemas := make([]Inconsistencia, 0)
+	ids := make(map[int]string) // todas las entidades comparten la secuencia de IDs
+	idMaximo := 0
+	registrar := func(entidad string, id int) {
+		if anterior, ok := ids[id]; ok {
+			problemas = append(problemas, Inconsistencia{entidad, id, "ID repetido (también usado por " + anterior + ")"})
+		}
+		ids[id] = entidad
+		idMaximo = max(idMaximo, id)
+	}
+	for _, libro := range b.Libros {
+		registrar("libro", libro.ID)
+	}
+	for _, usuario := range b.Usuarios {
+		registrar("usuario", usuario.ID)
+	}
+
+	prestamoActivo := make(map[int]int) // libro → préstamo activo
+	for _, prestamo := range b.Prestamos {
+		registrar("prestamo", prestamo.ID)
+		if prestamo.Devuelto {
+			continue
+		}
+		libro := b.BuscarLibro(prestamo.LibroID)
+		switch {
+		case libro == nil:
+			problemas = append(problemas, Inconsistencia{"prestamo", prestamo.ID,
+				fmt.Sprintf("referencia al libro inexistente %d", prestamo.LibroID)})
+		case !libro.Prestado:
+			problemas = append(problemas, Inconsistencia{"prestamo", prestamo.ID,
+				fmt.Sprintf("el libro %d no figura como prestado", prestamo.LibroID)})
+		}
+		usuario := b.BuscarUsuario(prestamo.UsuarioID)
+		switch {
+		case usuario == nil:
+			problemas = append(problemas, Inconsistencia{"prestamo", prestamo.ID,
+				fmt.Sprintf("referencia al usuario inexistente %d", prestamo.UsuarioID)})
+		case !usuario.Activo:
+			problemas = append(problemas, Inconsistencia{"prestamo", prestamo.ID,
+				fmt.Sprintf("el usuario %d no está activo", prestamo.UsuarioID)})
+		}
+		if otro, ok := prestamoActivo[prestamo.LibroID]; ok {
+			problemas = append(problemas, Inconsistencia{"prestamo", prestamo.ID,
+				fmt.Sprintf("el libro %d ya tiene el préstamo activo %d", prestamo.LibroID, otro)})
+		}
+		prestamoActivo[prestamo.LibroID] = prestamo.ID
+	}
+
+	for _, libro := range b.Libros {
+		if _, ok := prestamoActivo[libro.ID]; libro.Prestado && !ok {
+			problemas = append(problemas, Inconsistencia{"libro", libro.ID, "figura como prestado sin préstamo activo"})
+		}
+	}
+	for _, multa := range b.Multas {
+		registrar("multa", multa.ID)
+		if b.BuscarUsuario(multa.UsuarioID) == nil {
+			problemas = append(problemas, Inconsistencia{"multa", multa.ID,
+				fmt.Sprintf("referencia al usuario inexistente %d", multa.UsuarioID)})
+		}
+	}
+	if b.proximoID <= idMaximo {
+		problemas = append(problemas, Inconsistencia{"biblioteca", b.proximoID,
+			fmt.Sprintf("el próximo ID no supera al mayor ID usado (%d)", idMaximo)})
+	}
+	return problemas
+}
+
+// cargarInstantanea reemplaza los datos conservando la configuración.
+// Un respaldo anterior a un borrado todavía tiene los datos personales
+// del usuario: se vuelven a borrar los de todos los usuarios anonimizados,
+// sea que el borrado conste en la biblioteca o en el respaldo.
+func (b *Biblioteca) cargarInstantanea(i Instantanea) {
+	b.Nombre = i.Nombre
+	b.Direccion = i.Direccion
+	b.Libros = append([]Libro(nil), i.Libros...)
+	b.Usuarios = append([]Usuario(nil), i.Usuarios...)
+	b.Prestamos = append([]Prestamo(nil), i.Prestamos...)
+	b.Multas = append([]Multa(nil), i.Multas...)
+	b.MultaDiaria = i.MultaDiaria
+	b.Idioma = i.Idioma
+	b.proximoID = i.ProximoID
+
+	anonimizados := append([]int(nil), b.anonimizados...)
+	for _, id := range i.Anonimizados {
+		if !slices.Contains(anonimizados, id) {
+			anonimizados = append(anonimizados, id)
+		}
+	}
+	b.anonimizados = anonimizados
+	for _, id := range b.anonimizados {
+		if usuario := b.BuscarUsuario(id); usuario != nil {
+			borrarDatosPersonales(usuario)
+		}
+	}
+}
+
+// nombreLibre arma el nombre del respaldo para la fecha, agregando un
+// sufijo si ya existe uno en el mismo segundo
+func (g *GestorRespaldos) nombreLibre(fecha time.Time) string {
+	base := "respaldo-" + fecha.UTC().Format(formatoNombreRespaldo)
+	nombre := base + ".json.gz"
+	for n := 1; ; n++ {
+		if _, err := os.Stat(filepath.Join(g.Directorio, nombre)); os.IsNotExist(err) {
+			return nombre
+		}
+		nombre = fmt.Sprintf("%s-%02d.json.gz", base, n)
+	}
+}
+
+// fechaDeRespaldo extrae la fecha del nombre de un archivo de respaldo
+func fechaDeRespaldo(nombre string) (time.Time, bool) {
+	if !strings.HasPrefix(nombre, "respaldo-") || !strings.HasSuffix(nombre, ".json.gz") {
+		return time.Time{}, false
+	}
+	sello := strings.TrimPrefix(nombre, "respaldo-")[:min(len(formatoNombreRespaldo), len(nombre)-len("respaldo-"))]
+	fecha, err := time.Parse(formatoNombreRespaldo, sello)
+	return fecha, err == nil
+}
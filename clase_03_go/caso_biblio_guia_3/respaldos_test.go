@@ -0,0 +1,234 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// bibliotecaRespaldo arma una biblioteca con un préstamo activo y reloj fijo
+func bibliotecaRespaldo(t *testing.T) (*Biblioteca, *RelojFalso) {
+	t.Helper()
+	b := NuevaBiblioteca("Biblioteca Central", "Av. Principal 123")
+	reloj := NuevoRelojFalso(time.Date(2026, time.March, 2, 10, 0, 0, 0, time.UTC))
+	b.Reloj = reloj
+	libro, _ := b.AgregarLibro("Rayuela", "Julio Cortázar", "", 736)
+	b.AgregarLibro("Ficciones", "Jorge Luis Borges", "", 174)
+	usuario, _ := b.RegistrarUsuario("Ana García", "ana@email.com", "")
+	if err := b.PrestarLibro(libro.ID, usuario.ID); err != nil {
+		t.Fatal(err)
+	}
+	return b, reloj
+}
+
+// estadoJSON resume los datos de la biblioteca para compararlos; la
+// fecha de la instantánea no cuenta
+func estadoJSON(t *testing.T, b *Biblioteca) string {
+	t.Helper()
+	instantanea := b.Instantanea()
+	instantanea.Fecha = time.Time{}
+	datos, err := json.Marshal(instantanea)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(datos)
+}
+
+func nuevoGestorPrueba(t *testing.T, retener int) *GestorRespaldos {
+	t.Helper()
+	g, err := NuevoGestorRespaldos(t.TempDir(), retener)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestRespaldarYRestaurar(t *testing.T) {
+	b, _ := bibliotecaRespaldo(t)
+	g := nuevoGestorPrueba(t, 0)
+	original := estadoJSON(t, b)
+	respaldo, err := g.Respaldar(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b.DevolverLibro(b.Libros[0].ID)
+	b.AgregarLibro("El principito", "Antoine de Saint-Exupéry", "", 96)
+	if estadoJSON(t, b) == original {
+		t.Fatal("la biblioteca no cambió después del respaldo")
+	}
+
+	problemas, err := g.Restaurar(b, respaldo.Nombre)
+	if err != nil || len(problemas) > 0 {
+		t.Fatalf("Restaurar: %v %v", err, problemas)
+	}
+	if got := estadoJSON(t, b); got != original {
+		t.Errorf("estado restaurado:\n%s\nse esperaba:\n%s", got, original)
+	}
+}
+
+func TestRespaldoAlterado(t *testing.T) {
+	b, _ := bibliotecaRespaldo(t)
+	g := nuevoGestorPrueba(t, 0)
+	respaldo, err := g.Respaldar(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Verificar(respaldo.Nombre); err != nil {
+		t.Fatalf("el respaldo recién escrito no pasa la verificación: %v", err)
+	}
+
+	datos, err := os.ReadFile(respaldo.Ruta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	datos[len(datos)/2] ^= 0xff
+	if err := os.WriteFile(respaldo.Ruta, datos, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.Verificar(respaldo.Nombre); err == nil || !strings.Contains(err.Error(), "está dañado") {
+		t.Errorf("Verificar aceptó un respaldo alterado: %v", err)
+	}
+	antes := estadoJSON(t, b)
+	if _, err := g.Restaurar(b, respaldo.Nombre); err == nil {
+		t.Error("Restaurar aceptó un respaldo alterado")
+	}
+	if estadoJSON(t, b) != antes {
+		t.Error("un respaldo alterado modificó la biblioteca")
+	}
+}
+
+func TestRotarRespaldos(t *testing.T) {
+	b, reloj := bibliotecaRespaldo(t)
+	g := nuevoGestorPrueba(t, 3)
+	var nombres []string
+	for range 5 {
+		respaldo, err := g.Respaldar(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nombres = append(nombres, respaldo.Nombre)
+		reloj.Avanzar(time.Hour)
+	}
+
+	respaldos, err := g.Listar()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(respaldos) != 3 {
+		t.Fatalf("quedaron %d respaldos, se esperaban 3", len(respaldos))
+	}
+	for i, respaldo := range respaldos {
+		if respaldo.Nombre != nombres[i+2] {
+			t.Errorf("respaldo %d: %s, se esperaba %s", i, respaldo.Nombre, nombres[i+2])
+		}
+	}
+	// Con cada respaldo borrado se va también su suma
+	entradas, _ := os.ReadDir(g.Directorio)
+	if len(entradas) != 6 {
+		t.Errorf("quedaron %d archivos, se esperaban 3 respaldos y 3 sumas", len(entradas))
+	}
+}
+
+func TestRestaurarHasta(t *testing.T) {
+	b, reloj := bibliotecaRespaldo(t)
+	g := nuevoGestorPrueba(t, 0)
+	inicio := reloj.Ahora()
+	// Un respaldo por hora, cada uno con un libro más
+	for _, titulo := range []string{"Uno", "Dos", "Tres"} {
+		b.AgregarLibro(titulo, "Autor", "", 100)
+		if _, err := g.Respaldar(b); err != nil {
+			t.Fatal(err)
+		}
+		reloj.Avanzar(time.Hour)
+	}
+
+	casos := []struct {
+		fecha  time.Time
+		libros int // 0 si no debe haber respaldo
+	}{
+		{inicio.Add(-time.Minute), 0},
+		{inicio, 3},
+		{inicio.Add(90 * time.Minute), 4},
+		{inicio.Add(24 * time.Hour), 5},
+	}
+	for _, caso := range casos {
+		respaldo, _, err := g.RestaurarHasta(b, caso.fecha)
+		if caso.libros == 0 {
+			if err == nil {
+				t.Errorf("%s: se restauró %s y no había respaldos anteriores", caso.fecha, respaldo.Nombre)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", caso.fecha, err)
+			continue
+		}
+		if respaldo.Fecha.After(caso.fecha) || len(b.Libros) != caso.libros {
+			t.Errorf("%s: se restauró %s con %d libros, se esperaban %d",
+				caso.fecha, respaldo.Nombre, len(b.Libros), caso.libros)
+		}
+	}
+}
+
+func TestRestaurarInconsistente(t *testing.T) {
+	origen, _ := bibliotecaRespaldo(t)
+	origen.Libros[1].Prestado = true // sin préstamo que lo respalde
+	g := nuevoGestorPrueba(t, 0)
+	respaldo, err := g.Respaldar(origen)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := NuevaBiblioteca("Biblioteca Norte", "")
+	b.AgregarLibro("El principito", "Antoine de Saint-Exupéry", "", 96)
+	antes := estadoJSON(t, b)
+	problemas, err := g.Restaurar(b, respaldo.Nombre)
+	if err == nil {
+		t.Fatal("se restauró un respaldo inconsistente")
+	}
+	if len(problemas) != 1 || problemas[0].Entidad != "libro" || problemas[0].ID != origen.Libros[1].ID {
+		t.Errorf("inconsistencias %v", problemas)
+	}
+	if estadoJSON(t, b) != antes {
+		t.Error("la biblioteca cambió pese a las inconsistencias")
+	}
+}
+
+func TestRestaurarConservaAnonimizados(t *testing.T) {
+	b, _ := bibliotecaRespaldo(t)
+	g := nuevoGestorPrueba(t, 0)
+	luis, _ := b.RegistrarUsuario("Luis Pérez", "luis@email.com", "")
+	// El respaldo todavía tiene los datos de Luis
+	respaldo, err := g.Respaldar(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := b.AnonimizarUsuario(luis.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := g.Restaurar(b, respaldo.Nombre); err != nil {
+		t.Fatal(err)
+	}
+	usuario := b.BuscarUsuario(luis.ID)
+	if usuario == nil || !usuario.Anonimizado || usuario.Email != "" || strings.Contains(usuario.Nombre, "Luis") {
+		t.Errorf("el usuario volvió con sus datos personales: %+v", usuario)
+	}
+
+	// Un respaldo posterior al borrado lo lleva consigo a otra biblioteca
+	posterior, err := g.Respaldar(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otra := NuevaBiblioteca("Biblioteca Norte", "")
+	if _, err := g.Restaurar(otra, posterior.Nombre); err != nil {
+		t.Fatal(err)
+	}
+	if usuario := otra.BuscarUsuario(luis.ID); usuario == nil || !usuario.Anonimizado {
+		t.Errorf("el usuario anonimizado no llegó anonimizado: %+v", usuario)
+	}
+}
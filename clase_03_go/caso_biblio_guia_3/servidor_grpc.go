@@ -0,0 +1,294 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"sync"
+
+	"biblio/bibliotecapb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ==========================================
+// SERVIDOR gRPC
+// ==========================================
+
+// bufferEventos es cuántos eventos puede acumular un observador lento
+// antes de que se descarten los siguientes
+const bufferEventos = 64
+
+// ServidorGRPC expone una Biblioteca por gRPC. Biblioteca no es segura
+// para uso concurrente, así que todas las llamadas pasan por un mutex.
+type ServidorGRPC struct {
+	bibliotecapb.UnimplementedBibliotecaServer
+
+	mu         sync.Mutex
+	biblioteca *Biblioteca
+
+	muObservadores sync.Mutex
+	observadores   map[chan Evento]struct{}
+}
+
+// NuevoServidorGRPC envuelve la biblioteca y se suscribe a sus eventos
+func NuevoServidorGRPC(b *Biblioteca) *ServidorGRPC {
+	s := &ServidorGRPC{
+		biblioteca:   b,
+		observadores: make(map[chan Evento]struct{}),
+	}
+	b.Suscribir(s.difundir)
+	return s
+}
+
+// Registrar agrega el servicio a un servidor gRPC
+func (s *ServidorGRPC) Registrar(servidor *grpc.Server) {
+	bibliotecapb.RegisterBibliotecaServer(servidor, s)
+}
+
+// Servir atiende conexiones gRPC en la dirección dada hasta que falle
+func (s *ServidorGRPC) Servir(direccion string) error {
+	lis, err := net.Listen("tcp", direccion)
+	if err != nil {
+		return fmt.Errorf("No se pudo escuchar en %s: %w", direccion, err)
+	}
+	servidor := grpc.NewServer()
+	s.Registrar(servidor)
+	return servidor.Serve(lis)
+}
+
+// ------------------------------------------
+// Libros
+// ------------------------------------------
+
+func (s *ServidorGRPC) AgregarLibro(_ context.Context, req *bibliotecapb.AgregarLibroRequest) (*bibliotecapb.Libro, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	libro, err := s.biblioteca.AgregarLibro(req.GetTitulo(), req.GetAutor(), req.GetIsbn(), int(req.GetPaginas()))
+	if err != nil {
+		return nil, estadoGRPC(err)
+	}
+	return libroPB(*libro), nil
+}
+
+func (s *ServidorGRPC) ObtenerLibro(_ context.Context, req *bibliotecapb.ObtenerLibroRequest) (*bibliotecapb.Libro, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	libro := s.biblioteca.BuscarLibro(int(req.GetId()))
+	if libro == nil {
+		return nil, estadoGRPC(errLibroNoEncontrado(int(req.GetId())))
+	}
+	return libroPB(*libro), nil
+}
+
+func (s *ServidorGRPC) ListarLibros(_ context.Context, req *bibliotecapb.ListarLibrosRequest) (*bibliotecapb.ListarLibrosResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resp := &bibliotecapb.ListarLibrosResponse{}
+	for _, libro := range s.biblioteca.BuscarLibros(req.GetConsulta()) {
+		if req.GetSoloDisponibles() && !libro.EsPrestable() {
+			continue
+		}
+		resp.Libros = append(resp.Libros, libroPB(*libro))
+	}
+	return resp, nil
+}
+
+// ------------------------------------------
+// Usuarios
+// ------------------------------------------
+
+func (s *ServidorGRPC) RegistrarUsuario(_ context.Context, req *bibliotecapb.RegistrarUsuarioRequest) (*bibliotecapb.Usuario, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	usuario, err := s.biblioteca.RegistrarUsuario(req.GetNombre(), req.GetEmail(), req.GetTelefono())
+	if err != nil {
+		return nil, estadoGRPC(err)
+	}
+	return usuarioPB(*usuario), nil
+}
+
+func (s *ServidorGRPC) ObtenerUsuario(_ context.Context, req *bibliotecapb.ObtenerUsuarioRequest) (*bibliotecapb.Usuario, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	usuario := s.biblioteca.BuscarUsuario(int(req.GetId()))
+	if usuario == nil {
+		return nil, estadoGRPC(errUsuarioNoEncontrado(int(req.GetId())))
+	}
+	return usuarioPB(*usuario), nil
+}
+
+// ------------------------------------------
+// Préstamos
+// ------------------------------------------
+
+func (s *ServidorGRPC) PrestarLibro(_ context.Context, req *bibliotecapb.PrestarLibroRequest) (*bibliotecapb.Prestamo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	libroID := int(req.GetLibroId())
+	if err := s.biblioteca.PrestarLibro(libroID, int(req.GetUsuarioId())); err != nil {
+		return nil, estadoGRPC(err)
+	}
+	return prestamoPB(*s.biblioteca.buscarPrestamoActivo(libroID)), nil
+}
+
+func (s *ServidorGRPC) DevolverLibro(_ context.Context, req *bibliotecapb.DevolverLibroRequest) (*bibliotecapb.Prestamo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	libroID := int(req.GetLibroId())
+	prestamo := s.biblioteca.buscarPrestamoActivo(libroID)
+	if err := s.biblioteca.DevolverLibro(libroID); err != nil {
+		return nil, estadoGRPC(err)
+	}
+	return prestamoPB(*prestamo), nil
+}
+
+func (s *ServidorGRPC) RenovarPrestamo(_ context.Context, req *bibliotecapb.RenovarPrestamoRequest) (*bibliotecapb.Prestamo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	libroID := int(req.GetLibroId())
+	if err := s.biblioteca.RenovarPrestamo(libroID); err != nil {
+		return nil, estadoGRPC(err)
+	}
+	return prestamoPB(*s.biblioteca.buscarPrestamoActivo(libroID)), nil
+}
+
+func (s *ServidorGRPC) ListarPrestamos(_ context.Context, req *bibliotecapb.ListarPrestamosRequest) (*bibliotecapb.ListarPrestamosResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resp := &bibliotecapb.ListarPrestamosResponse{}
+	for _, prestamo := range s.biblioteca.Prestamos {
+		if req.GetUsuarioId() != 0 && prestamo.UsuarioID != int(req.GetUsuarioId()) {
+			continue
+		}
+		if req.GetSoloActivos() && prestamo.Devuelto {
+			continue
+		}
+		resp.Prestamos = append(resp.Prestamos, prestamoPB(prestamo))
+	}
+	return resp, nil
+}
+
+// ------------------------------------------
+// Eventos en vivo
+// ------------------------------------------
+
+// ObservarEventos envía al cliente cada evento publicado desde que se
+// conecta hasta que cancela la llamada
+func (s *ServidorGRPC) ObservarEventos(req *bibliotecapb.ObservarEventosRequest, stream grpc.ServerStreamingServer[bibliotecapb.Evento]) error {
+	canal := make(chan Evento, bufferEventos)
+	s.muObservadores.Lock()
+	s.observadores[canal] = struct{}{}
+	s.muObservadores.Unlock()
+	defer func() {
+		s.muObservadores.Lock()
+		delete(s.observadores, canal)
+		s.muObservadores.Unlock()
+	}()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case evento := <-canal:
+			if len(req.GetTipos()) > 0 && !slices.Contains(req.GetTipos(), string(evento.Tipo)) {
+				continue
+			}
+			if err := stream.Send(eventoPB(evento)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// difundir reparte un evento entre los observadores conectados. Se llama
+// con el mutex de la biblioteca tomado, así que nunca bloquea: si un
+// observador tiene el buffer lleno, pierde el evento.
+func (s *ServidorGRPC) difundir(evento Evento) {
+	s.muObservadores.Lock()
+	defer s.muObservadores.Unlock()
+	for canal := range s.observadores {
+		select {
+		case canal <- evento:
+		default:
+		}
+	}
+}
+
+// ------------------------------------------
+// Conversiones
+// ------------------------------------------
+
+// estadoGRPC traduce los errores de la biblioteca a códigos gRPC
+func estadoGRPC(err error) error {
+	codigo := codes.Internal
+	switch CodigoDe(err) {
+	case CodigoDatoRequerido, CodigoDatoInvalido:
+		codigo = codes.InvalidArgument
+	case CodigoLibroNoEncontrado, CodigoUsuarioNoEncontrado,
+		CodigoPrestamoNoEncontrado, CodigoMultaNoEncontrada:
+		codigo = codes.NotFound
+	case CodigoUsuarioNoHabilitado, CodigoPrestamoDenegado:
+		codigo = codes.PermissionDenied
+	case CodigoDuplicado:
+		codigo = codes.AlreadyExists
+	case CodigoLibroYaPrestado, CodigoLibroNoPrestado,
+		CodigoLibroNoDisponible, CodigoOperacionInvalida:
+		codigo = codes.FailedPrecondition
+	}
+	return status.Error(codigo, err.Error())
+}
+
+func libroPB(l Libro) *bibliotecapb.Libro {
+	return &bibliotecapb.Libro{
+		Id:        int64(l.ID),
+		Titulo:    l.Titulo,
+		Autor:     l.Autor,
+		Isbn:      l.ISBN,
+		Paginas:   int32(l.Paginas),
+		Prestado:  l.Prestado,
+		Estado:    string(l.Estado),
+		Ubicacion: l.Ubicacion,
+		Categoria: l.Categoria,
+	}
+}
+
+func usuarioPB(u Usuario) *bibliotecapb.Usuario {
+	return &bibliotecapb.Usuario{
+		Id:        int64(u.ID),
+		Nombre:    u.Nombre,
+		Email:     u.Email,
+		Telefono:  u.Telefono,
+		Activo:    u.Activo,
+		Categoria: u.Categoria,
+	}
+}
+
+func prestamoPB(p Prestamo) *bibliotecapb.Prestamo {
+	return &bibliotecapb.Prestamo{
+		Id:              int64(p.ID),
+		LibroId:         int64(p.LibroID),
+		UsuarioId:       int64(p.UsuarioID),
+		FechaPrestamo:   timestamppb.New(p.FechaPrestamo),
+		FechaDevolucion: timestamppb.New(p.FechaDevolucion),
+		Devuelto:        p.Devuelto,
+		Renovaciones:    int32(p.Renovaciones),
+		MaxRenovaciones: int32(p.MaxRenovaciones),
+	}
+}
+
+func eventoPB(e Evento) *bibliotecapb.Evento {
+	return &bibliotecapb.Evento{
+		Id:         e.ID,
+		Tipo:       string(e.Tipo),
+		Fecha:      timestamppb.New(e.Fecha),
+		LibroId:    int64(e.LibroID),
+		UsuarioId:  int64(e.UsuarioID),
+		PrestamoId: int64(e.PrestamoID),
+		MultaId:    int64(e.MultaID),
+	}
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"biblio/bibliotecapb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// conectarPrueba levanta el servicio sobre una conexión en memoria y
+// retorna un cliente; todo se cierra al terminar la prueba
+func conectarPrueba(t *testing.T) (*ServidorGRPC, bibliotecapb.BibliotecaClient) {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	servicio := NuevoServidorGRPC(NuevaBiblioteca("Biblioteca de Pruebas", ""))
+	servidor := grpc.NewServer()
+	servicio.Registrar(servidor)
+	go servidor.Serve(lis)
+	t.Cleanup(servidor.Stop)
+
+	conexion, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conexion.Close() })
+	return servicio, bibliotecapb.NewBibliotecaClient(conexion)
+}
+
+// esperarCodigo verifica que la llamada falló con el código gRPC dado
+func esperarCodigo(t *testing.T, err error, esperado codes.Code) {
+	t.Helper()
+	if got := status.Code(err); got != esperado {
+		t.Errorf("código %v (%v), se esperaba %v", got, err, esperado)
+	}
+}
+
+func contextoPrueba(t *testing.T) context.Context {
+	ctx, cancelar := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancelar)
+	return ctx
+}
+
+func TestGRPCLibros(t *testing.T) {
+	_, cliente := conectarPrueba(t)
+	ctx := contextoPrueba(t)
+
+	quijote, err := cliente.AgregarLibro(ctx, &bibliotecapb.AgregarLibroRequest{
+		Titulo: "El Quijote", Autor: "Miguel de Cervantes", Isbn: "978-84-376-0494-7", Paginas: 863})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if quijote.GetId() == 0 || quijote.GetTitulo() != "El Quijote" || quijote.GetPrestado() {
+		t.Errorf("libro inesperado: %v", quijote)
+	}
+	if _, err := cliente.AgregarLibro(ctx, &bibliotecapb.AgregarLibroRequest{
+		Titulo: "Rayuela", Autor: "Julio Cortázar", Isbn: "978-84-376-0457-2", Paginas: 736}); err != nil {
+		t.Fatal(err)
+	}
+
+	obtenido, err := cliente.ObtenerLibro(ctx, &bibliotecapb.ObtenerLibroRequest{Id: quijote.GetId()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obtenido.GetIsbn() != "978-84-376-0494-7" || obtenido.GetPaginas() != 863 {
+		t.Errorf("ObtenerLibro = %v", obtenido)
+	}
+
+	listado, err := cliente.ListarLibros(ctx, &bibliotecapb.ListarLibrosRequest{Consulta: "cortázar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listado.GetLibros()) != 1 || listado.GetLibros()[0].GetTitulo() != "Rayuela" {
+		t.Errorf("ListarLibros(cortázar) = %v", listado.GetLibros())
+	}
+	todos, err := cliente.ListarLibros(ctx, &bibliotecapb.ListarLibrosRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(todos.GetLibros()) != 2 {
+		t.Errorf("ListarLibros() retornó %d libros, se esperaban 2", len(todos.GetLibros()))
+	}
+}
+
+func TestGRPCUsuarios(t *testing.T) {
+	_, cliente := conectarPrueba(t)
+	ctx := contextoPrueba(t)
+
+	ana, err := cliente.RegistrarUsuario(ctx, &bibliotecapb.RegistrarUsuarioRequest{
+		Nombre: "Ana", Email: "ana@gmail.com", Telefono: "987 654 321"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ana.GetTelefono() != "+51987654321" || !ana.GetActivo() {
+		t.Errorf("usuario inesperado: %v", ana)
+	}
+	obtenido, err := cliente.ObtenerUsuario(ctx, &bibliotecapb.ObtenerUsuarioRequest{Id: ana.GetId()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obtenido.GetNombre() != "Ana" || obtenido.GetEmail() != "ana@gmail.com" {
+		t.Errorf("ObtenerUsuario = %v", obtenido)
+	}
+}
+
+func TestGRPCPrestamos(t *testing.T) {
+	_, cliente := conectarPrueba(t)
+	ctx := contextoPrueba(t)
+
+	libro, err := cliente.AgregarLibro(ctx, &bibliotecapb.AgregarLibroRequest{
+		Titulo: "Ficciones", Autor: "Jorge Luis Borges", Paginas: 174})
+	if err != nil {
+		t.Fatal(err)
+	}
+	usuario, err := cliente.RegistrarUsuario(ctx, &bibliotecapb.RegistrarUsuarioRequest{
+		Nombre: "Ana", Email: "ana@gmail.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prestamo, err := cliente.PrestarLibro(ctx, &bibliotecapb.PrestarLibroRequest{
+		LibroId: libro.GetId(), UsuarioId: usuario.GetId()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prestamo.GetLibroId() != libro.GetId() || prestamo.GetUsuarioId() != usuario.GetId() || prestamo.GetDevuelto() {
+		t.Errorf("préstamo inesperado: %v", prestamo)
+	}
+	dias := prestamo.GetFechaDevolucion().AsTime().Sub(prestamo.GetFechaPrestamo().AsTime()).Hours() / 24
+	if dias < diasPrestamo {
+		t.Errorf("el préstamo vence a los %.1f días, se esperaban al menos %d", dias, diasPrestamo)
+	}
+
+	renovado, err := cliente.RenovarPrestamo(ctx, &bibliotecapb.RenovarPrestamoRequest{LibroId: libro.GetId()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if renovado.GetRenovaciones() != 1 || !renovado.GetFechaDevolucion().AsTime().After(prestamo.GetFechaDevolucion().AsTime()) {
+		t.Errorf("renovación inesperada: %v", renovado)
+	}
+
+	activos, err := cliente.ListarPrestamos(ctx, &bibliotecapb.ListarPrestamosRequest{
+		UsuarioId: usuario.GetId(), SoloActivos: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(activos.GetPrestamos()) != 1 {
+		t.Errorf("ListarPrestamos retornó %d préstamos activos, se esperaba 1", len(activos.GetPrestamos()))
+	}
+
+	devuelto, err := cliente.DevolverLibro(ctx, &bibliotecapb.DevolverLibroRequest{LibroId: libro.GetId()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !devuelto.GetDevuelto() || devuelto.GetId() != prestamo.GetId() {
+		t.Errorf("devolución inesperada: %v", devuelto)
+	}
+	activos, err = cliente.ListarPrestamos(ctx, &bibliotecapb.ListarPrestamosRequest{SoloActivos: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(activos.GetPrestamos()) != 0 {
+		t.Errorf("quedaron %d préstamos activos tras la devolución", len(activos.GetPrestamos()))
+	}
+}
+
+func TestGRPCCodigosDeError(t *testing.T) {
+	servicio, cliente := conectarPrueba(t)
+	ctx := contextoPrueba(t)
+
+	libro, err := cliente.AgregarLibro(ctx, &bibliotecapb.AgregarLibroRequest{
+		Titulo: "El principito", Autor: "Antoine de Saint-Exupéry", Isbn: "978-0156012195", Paginas: 96})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ana, err := cliente.RegistrarUsuario(ctx, &bibliotecapb.RegistrarUsuarioRequest{Nombre: "Ana", Email: "ana@gmail.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	carlos, err := cliente.RegistrarUsuario(ctx, &bibliotecapb.RegistrarUsuarioRequest{Nombre: "Carlos", Email: "carlos@gmail.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = cliente.AgregarLibro(ctx, &bibliotecapb.AgregarLibroRequest{Autor: "Sin título"})
+	esperarCodigo(t, err, codes.InvalidArgument)
+	_, err = cliente.AgregarLibro(ctx, &bibliotecapb.AgregarLibroRequest{
+		Titulo: "Otra edición", Autor: "Otro", Isbn: "978-0156012195", Paginas: 10})
+	esperarCodigo(t, err, codes.AlreadyExists)
+	_, err = cliente.RegistrarUsuario(ctx, &bibliotecapb.RegistrarUsuarioRequest{Nombre: "Ana", Email: "no-es-un-correo"})
+	esperarCodigo(t, err, codes.InvalidArgument)
+
+	_, err = cliente.ObtenerLibro(ctx, &bibliotecapb.ObtenerLibroRequest{Id: 999})
+	esperarCodigo(t, err, codes.NotFound)
+	_, err = cliente.ObtenerUsuario(ctx, &bibliotecapb.ObtenerUsuarioRequest{Id: 999})
+	esperarCodigo(t, err, codes.NotFound)
+	_, err = cliente.DevolverLibro(ctx, &bibliotecapb.DevolverLibroRequest{LibroId: libro.GetId()})
+	esperarCodigo(t, err, codes.NotFound)
+	_, err = cliente.RenovarPrestamo(ctx, &bibliotecapb.RenovarPrestamoRequest{LibroId: libro.GetId()})
+	esperarCodigo(t, err, codes.NotFound)
+
+	servicio.mu.Lock()
+	servicio.biblioteca.BuscarUsuario(int(carlos.GetId())).Desactivar()
+	servicio.mu.Unlock()
+	_, err = cliente.PrestarLibro(ctx, &bibliotecapb.PrestarLibroRequest{LibroId: libro.GetId(), UsuarioId: carlos.GetId()})
+	esperarCodigo(t, err, codes.PermissionDenied)
+
+	if _, err := cliente.PrestarLibro(ctx, &bibliotecapb.PrestarLibroRequest{
+		LibroId: libro.GetId(), UsuarioId: ana.GetId()}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = cliente.PrestarLibro(ctx, &bibliotecapb.PrestarLibroRequest{LibroId: libro.GetId(), UsuarioId: ana.GetId()})
+	esperarCodigo(t, err, codes.FailedPrecondition)
+}
+
+func TestGRPCObservarEventos(t *testing.T) {
+	servicio, cliente := conectarPrueba(t)
+	ctx := contextoPrueba(t)
+
+	libro, err := cliente.AgregarLibro(ctx, &bibliotecapb.AgregarLibroRequest{
+		Titulo: "Rayuela", Autor: "Julio Cortázar", Paginas: 736})
+	if err != nil {
+		t.Fatal(err)
+	}
+	usuario, err := cliente.RegistrarUsuario(ctx, &bibliotecapb.RegistrarUsuarioRequest{Nombre: "Ana", Email: "ana@gmail.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream, err := cliente.ObservarEventos(ctx, &bibliotecapb.ObservarEventosRequest{
+		Tipos: []string{string(EventoLibroPrestado), string(EventoLibroDevuelto)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// El observador se registra en el servidor cuando llega la llamada;
+	// los eventos anteriores no se reenvían
+	for {
+		servicio.muObservadores.Lock()
+		conectados := len(servicio.observadores)
+		servicio.muObservadores.Unlock()
+		if conectados == 1 {
+			break
+		}
+		if ctx.Err() != nil {
+			t.Fatal("el observador nunca se registró")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	prestamo, err := cliente.PrestarLibro(ctx, &bibliotecapb.PrestarLibroRequest{
+		LibroId: libro.GetId(), UsuarioId: usuario.GetId()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Los renovados no están en el filtro y no deben llegar
+	if _, err := cliente.RenovarPrestamo(ctx, &bibliotecapb.RenovarPrestamoRequest{LibroId: libro.GetId()}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cliente.DevolverLibro(ctx, &bibliotecapb.DevolverLibroRequest{LibroId: libro.GetId()}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, esperado := range []TipoEvento{EventoLibroPrestado, EventoLibroDevuelto} {
+		evento, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if evento.GetTipo() != string(esperado) {
+			t.Fatalf("se recibió %s, se esperaba %s", evento.GetTipo(), esperado)
+		}
+		if evento.GetLibroId() != libro.GetId() || evento.GetUsuarioId() != usuario.GetId() ||
+			evento.GetPrestamoId() != prestamo.GetId() || evento.GetId() == "" {
+			t.Errorf("evento %s con datos inesperados: %v", esperado, evento)
+		}
+	}
+}
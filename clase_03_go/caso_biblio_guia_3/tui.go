@@ -0,0 +1,427 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// ==========================================
+// MOSTRADOR: INTERFAZ DE TERMINAL
+// ==========================================
+
+// Tecla es una pulsación ya decodificada
+type Tecla struct {
+	Runa     rune
+	Especial string // "arriba", "abajo", "tab", "enter", "esc", "borrar", "ctrl+c"; vacío si es Runa
+}
+
+// panelMostrador indica qué lista recibe las flechas
+type panelMostrador int
+
+const (
+	panelLibros panelMostrador = iota
+	panelUsuarios
+)
+
+// Secuencias ANSI usadas al dibujar
+const (
+	ansiLimpiar   = "\x1b[H\x1b[2J"
+	ansiInvertido = "\x1b[7m"
+	ansiNegrita   = "\x1b[1m"
+	ansiNormal    = "\x1b[0m"
+	ansiOcultar   = "\x1b[?25l"
+	ansiMostrar   = "\x1b[?25h"
+	ansiAlterna   = "\x1b[?1049h" // pantalla alterna: no ensucia el historial
+	ansiPrincipal = "\x1b[?1049l"
+)
+
+// Mostrador guarda el estado de la pantalla. No toca la terminal, así
+// que se puede manejar con teclas simuladas y dibujar sobre cualquier Writer.
+type Mostrador struct {
+	biblioteca *Biblioteca
+	Ancho      int
+	Alto       int
+
+	panel         panelMostrador
+	consulta      string
+	buscando      bool // la entrada de teclado va a la consulta
+	cursorLibro   int
+	cursorUsuario int
+	mensaje       string
+	terminado     bool
+	librosVistos  []*Libro
+}
+
+// NuevoMostrador crea la pantalla para una biblioteca
+func NuevoMostrador(b *Biblioteca, ancho, alto int) *Mostrador {
+	m := &Mostrador{biblioteca: b, Ancho: ancho, Alto: alto}
+	m.filtrar()
+	return m
+}
+
+// Terminado indica si el usuario pidió salir
+func (m *Mostrador) Terminado() bool {
+	return m.terminado
+}
+
+// Manejar aplica una tecla al estado de la pantalla
+func (m *Mostrador) Manejar(t Tecla) {
+	// Ctrl+C sale siempre, también mientras se escribe la consulta
+	if t.Especial == "ctrl+c" {
+		m.terminado = true
+		return
+	}
+	if m.buscando {
+		m.manejarBusqueda(t)
+		return
+	}
+	m.mensaje = ""
+	switch {
+	case t.Especial == "arriba":
+		m.mover(-1)
+	case t.Especial == "abajo":
+		m.mover(1)
+	case t.Especial == "tab":
+		m.panel = 1 - m.panel
+	case t.Runa == '/':
+		m.buscando = true
+	case t.Runa == 'q':
+		m.terminado = true
+	case t.Runa == 'p':
+		m.operar("Prestado", func(libro *Libro) error {
+			usuario := m.usuarioSeleccionado()
+			if usuario == nil {
+				return fmt.Errorf("No hay usuarios registrados")
+			}
+			return m.biblioteca.PrestarLibro(libro.ID, usuario.ID)
+		})
+	case t.Runa == 'd':
+		m.operar("Devuelto", func(libro *Libro) error {
+			return m.biblioteca.DevolverLibro(libro.ID)
+		})
+	case t.Runa == 'r':
+		m.operar("Renovado", func(libro *Libro) error {
+			return m.biblioteca.RenovarPrestamo(libro.ID)
+		})
+	}
+}
+
+// Dibujar escribe la pantalla completa
+func (m *Mostrador) Dibujar(w io.Writer) {
+	var sb strings.Builder
+	sb.WriteString(ansiLimpiar)
+	b := m.biblioteca
+	idioma := b.idioma()
+
+	// Cabecera y búsqueda
+	titulo := fmt.Sprintf(" %s — Mostrador", b.Nombre)
+	sb.WriteString(ansiNegrita + ajustar(titulo, m.Ancho) + ansiNormal + "\r\n")
+	busqueda := " Buscar: " + m.consulta
+	if m.buscando {
+		busqueda += "▏"
+	}
+	sb.WriteString(ajustar(busqueda, m.Ancho) + "\r\n")
+
+	// El espacio restante se reparte entre libros y usuarios; el pie
+	// ocupa tres líneas
+	disponible := max(m.Alto-2-3-4, 2)
+	altoLibros := disponible * 3 / 5
+	altoUsuarios := disponible - altoLibros
+
+	sb.WriteString(m.encabezado(" LIBROS", m.panel == panelLibros) + "\r\n")
+	filas := make([]string, 0, len(m.librosVistos))
+	for _, libro := range m.librosVistos {
+		estado := idioma.Texto("estado.disponible")
+		if libro.Prestado {
+			estado = idioma.Texto("estado.prestado")
+		} else if libro.Estado != EnCirculacion {
+			estado = idioma.Texto("estado." + string(libro.Estado))
+		}
+		fila := fmt.Sprintf(" %4d  %-30s %-20s %-14s", libro.ID,
+			recortar(libro.Titulo, 30), recortar(libro.Autor, 20), recortar(estado, 14))
+		if libro.EsGrande() {
+			fila += " " + idioma.Texto("listado.extenso", libro.Paginas, idioma.Plural("pagina", libro.Paginas))
+		}
+		filas = append(filas, fila)
+	}
+	m.escribirLista(&sb, filas, m.cursorLibro, altoLibros, m.panel == panelLibros)
+
+	sb.WriteString(m.encabezado(" USUARIOS", m.panel == panelUsuarios) + "\r\n")
+	filas = filas[:0]
+	for _, usuario := range b.Usuarios {
+		filas = append(filas, fmt.Sprintf(" %4d  %s", usuario.ID, usuario.ObtenerResumen()))
+	}
+	altoLista := max(altoUsuarios/2, 1)
+	m.escribirLista(&sb, filas, m.cursorUsuario, altoLista, m.panel == panelUsuarios)
+
+	sb.WriteString(ajustar(" Préstamos activos:", m.Ancho) + "\r\n")
+	prestamos := make([]string, 0)
+	if usuario := m.usuarioSeleccionado(); usuario != nil {
+		for _, prestamo := range b.Prestamos {
+			if prestamo.UsuarioID != usuario.ID || prestamo.Devuelto {
+				continue
+			}
+			tituloLibro := "?"
+			if libro := b.BuscarLibro(prestamo.LibroID); libro != nil {
+				tituloLibro = libro.Titulo
+			}
+			vencido := ""
+			if b.ahora().After(prestamo.FechaDevolucion) {
+				vencido = "  VENCIDO"
+			}
+			prestamos = append(prestamos, fmt.Sprintf("   • %s — vence %s%s", tituloLibro,
+				idioma.FormatearFecha(prestamo.FechaDevolucion), vencido))
+		}
+	}
+	m.escribirLista(&sb, prestamos, -1, altoUsuarios-altoLista-1, false)
+
+	// Pie: estadísticas, ayuda y resultado de la última acción
+	sb.WriteString(ajustar(strings.Repeat("─", max(m.Ancho, 1)), m.Ancho) + "\r\n")
+	sb.WriteString(ajustar(" "+m.resumenEstadisticas(), m.Ancho) + "\r\n")
+	ayuda := " ↑↓ mover  Tab panel  / buscar  p prestar  d devolver  r renovar  q salir"
+	if m.buscando {
+		ayuda = " Escriba para filtrar  Enter aceptar  Esc limpiar"
+	}
+	sb.WriteString(ansiInvertido + ajustar(ayuda, m.Ancho) + ansiNormal + "\r\n")
+	sb.WriteString(ajustar(" "+m.mensaje, m.Ancho))
+
+	io.WriteString(w, sb.String())
+}
+
+// EjecutarMostrador toma la terminal y atiende teclas hasta que se pulse q
+func EjecutarMostrador(b *Biblioteca) error {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return fmt.Errorf("El mostrador necesita una terminal interactiva")
+	}
+	estadoPrevio, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("No se pudo preparar la terminal: %w", err)
+	}
+	defer term.Restore(fd, estadoPrevio)
+
+	salida := bufio.NewWriter(os.Stdout)
+	salida.WriteString(ansiAlterna + ansiOcultar)
+	defer func() {
+		salida.WriteString(ansiMostrar + ansiPrincipal)
+		salida.Flush()
+	}()
+
+	ancho, alto, err := term.GetSize(fd)
+	if err != nil {
+		ancho, alto = 100, 30
+	}
+	m := NuevoMostrador(b, ancho, alto)
+
+	teclas := make(chan Tecla)
+	go leerTeclas(os.Stdin, teclas)
+
+	// El refresco periódico mantiene al día vencimientos y estadísticas
+	refresco := time.NewTicker(time.Second)
+	defer refresco.Stop()
+	for !m.Terminado() {
+		if a, h, err := term.GetSize(fd); err == nil {
+			m.Ancho, m.Alto = a, h
+		}
+		m.Dibujar(salida)
+		salida.Flush()
+		select {
+		case tecla, ok := <-teclas:
+			if !ok {
+				return nil
+			}
+			m.Manejar(tecla)
+		case <-refresco.C:
+		}
+	}
+	return nil
+}
+
+// leerTeclas decodifica la entrada en modo crudo
+func leerTeclas(r io.Reader, teclas chan<- Tecla) {
+	defer close(teclas)
+	buf := make([]byte, 16)
+	for {
+		n, err := r.Read(buf)
+		if err != nil {
+			return
+		}
+		for _, t := range decodificarTeclas(buf[:n]) {
+			teclas <- t
+		}
+	}
+}
+
+// decodificarTeclas traduce bytes de la terminal a teclas
+func decodificarTeclas(datos []byte) []Tecla {
+	teclas := make([]Tecla, 0, len(datos))
+	for len(datos) > 0 {
+		switch {
+		case len(datos) >= 3 && datos[0] == 0x1b && datos[1] == '[':
+			switch datos[2] {
+			case 'A':
+				teclas = append(teclas, Tecla{Especial: "arriba"})
+			case 'B':
+				teclas = append(teclas, Tecla{Especial: "abajo"})
+			}
+			datos = datos[3:]
+			continue
+		case datos[0] == 0x1b:
+			teclas = append(teclas, Tecla{Especial: "esc"})
+		case datos[0] == '\t':
+			teclas = append(teclas, Tecla{Especial: "tab"})
+		case datos[0] == '\r' || datos[0] == '\n':
+			teclas = append(teclas, Tecla{Especial: "enter"})
+		case datos[0] == 0x7f || datos[0] == 0x08:
+			teclas = append(teclas, Tecla{Especial: "borrar"})
+		case datos[0] == 0x03: // Ctrl+C
+			teclas = append(teclas, Tecla{Especial: "ctrl+c"})
+		default:
+			r, tam := utf8.DecodeRune(datos)
+			teclas = append(teclas, Tecla{Runa: r})
+			datos = datos[tam:]
+			continue
+		}
+		datos = datos[1:]
+	}
+	return teclas
+}
+
+// ejecutarMostradorCLI abre el mostrador con datos de ejemplo
+func ejecutarMostradorCLI() int {
+	b := NuevaBiblioteca("Biblioteca Central", "Av. Principal 123")
+	b.SalidaPlana = true
+	if err := b.CargarPoliticasOpcionales(rutaPoliticas); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return 1
+	}
+	b.AgregarLibro("Cien años de soledad", "Gabriel García Márquez", "978-0307474728", 417)
+	b.AgregarLibro("El principito", "Antoine de Saint-Exupéry", "978-0156012195", 96)
+	b.AgregarLibro("Rayuela", "Julio Cortázar", "978-8437604572", 736)
+	b.AgregarLibro("Ficciones", "Jorge Luis Borges", "978-0802130303", 174)
+	b.RegistrarUsuario("Ana García", "ana@email.com", "987 654 321")
+	b.RegistrarUsuario("Carlos López", "carlos@email.com", "912 345 678")
+	if err := EjecutarMostrador(b); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func (m *Mostrador) manejarBusqueda(t Tecla) {
+	switch t.Especial {
+	case "enter":
+		m.buscando = false
+	case "esc":
+		m.buscando = false
+		m.consulta = ""
+	case "borrar":
+		if r := []rune(m.consulta); len(r) > 0 {
+			m.consulta = string(r[:len(r)-1])
+		}
+	case "":
+		m.consulta += string(t.Runa)
+	}
+	m.filtrar()
+}
+
+// operar ejecuta una acción sobre el libro seleccionado y deja el resultado en el pie
+func (m *Mostrador) operar(hecho string, accion func(*Libro) error) {
+	libro := m.libroSeleccionado()
+	if libro == nil {
+		m.mensaje = "No hay ningún libro seleccionado"
+		return
+	}
+	if err := accion(libro); err != nil {
+		m.mensaje = "Error: " + err.Error()
+		return
+	}
+	m.mensaje = fmt.Sprintf("%s: %s", hecho, libro.Titulo)
+	m.filtrar()
+}
+
+// filtrar recalcula la lista de libros visibles según la consulta
+func (m *Mostrador) filtrar() {
+	m.librosVistos = m.biblioteca.BuscarLibros(m.consulta)
+	m.cursorLibro = min(m.cursorLibro, max(len(m.librosVistos)-1, 0))
+}
+
+func (m *Mostrador) mover(delta int) {
+	if m.panel == panelLibros {
+		m.cursorLibro = min(max(m.cursorLibro+delta, 0), max(len(m.librosVistos)-1, 0))
+		return
+	}
+	m.cursorUsuario = min(max(m.cursorUsuario+delta, 0), max(len(m.biblioteca.Usuarios)-1, 0))
+}
+
+func (m *Mostrador) libroSeleccionado() *Libro {
+	if m.cursorLibro < len(m.librosVistos) {
+		return m.librosVistos[m.cursorLibro]
+	}
+	return nil
+}
+
+func (m *Mostrador) usuarioSeleccionado() *Usuario {
+	if m.cursorUsuario < len(m.biblioteca.Usuarios) {
+		return &m.biblioteca.Usuarios[m.cursorUsuario]
+	}
+	return nil
+}
+
+// resumenEstadisticas pone en una línea lo que ObtenerEstadisticas
+// muestra en varias, sin el título
+func (m *Mostrador) resumenEstadisticas() string {
+	b := *m.biblioteca
+	b.SalidaPlana = true
+	lineas := strings.Split(b.ObtenerEstadisticas(), "\n")
+	partes := make([]string, 0, len(lineas))
+	for _, linea := range lineas[1:] {
+		partes = append(partes, strings.TrimSpace(linea))
+	}
+	return strings.Join(partes, " │ ")
+}
+
+func (m *Mostrador) encabezado(texto string, activo bool) string {
+	if activo {
+		return ansiNegrita + ajustar(texto+" ◀", m.Ancho) + ansiNormal
+	}
+	return ajustar(texto, m.Ancho)
+}
+
+// escribirLista dibuja hasta alto filas, desplazando la ventana para
+// que el cursor siempre quede visible
+func (m *Mostrador) escribirLista(sb *strings.Builder, filas []string, cursor, alto int, activo bool) {
+	inicio := 0
+	if cursor >= alto {
+		inicio = cursor - alto + 1
+	}
+	for i := inicio; i < inicio+alto; i++ {
+		fila := ""
+		if i < len(filas) {
+			fila = filas[i]
+		}
+		if i == cursor && activo {
+			sb.WriteString(ansiInvertido + ajustar(fila, m.Ancho) + ansiNormal + "\r\n")
+			continue
+		}
+		sb.WriteString(ajustar(fila, m.Ancho) + "\r\n")
+	}
+}
+
+// ajustar recorta o rellena el texto al ancho de la pantalla. Algunas
+// terminales informan ancho 0; se dibuja al menos una columna.
+func ajustar(texto string, ancho int) string {
+	ancho = max(ancho, 1)
+	n := utf8.RuneCountInString(texto)
+	if n >= ancho {
+		return recortar(texto, ancho)
+	}
+	return texto + strings.Repeat(" ", ancho-n)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMostradorTeclas(t *testing.T) {
+	b := NuevaBiblioteca("Biblioteca Central", "")
+	b.SalidaPlana = true
+	b.AgregarLibro("El principito", "Antoine de Saint-Exupéry", "", 96)
+	rayuela, _ := b.AgregarLibro("Rayuela", "Julio Cortázar", "", 736)
+	ana, _ := b.RegistrarUsuario("Ana García", "ana@email.com", "")
+	carlos, _ := b.RegistrarUsuario("Carlos López", "carlos@email.com", "")
+
+	m := NuevoMostrador(b, 120, 30)
+	teclear := func(entrada string) string {
+		t.Helper()
+		for _, tecla := range decodificarTeclas([]byte(entrada)) {
+			m.Manejar(tecla)
+		}
+		var sb strings.Builder
+		m.Dibujar(&sb)
+		return sb.String()
+	}
+	prestadoA := func() int {
+		if prestamo := b.buscarPrestamoActivo(rayuela.ID); prestamo != nil {
+			return prestamo.UsuarioID
+		}
+		return 0
+	}
+
+	pantalla := teclear("/ray\r")
+	if !strings.Contains(pantalla, "Buscar: ray") || strings.Contains(pantalla, "El principito") ||
+		!strings.Contains(pantalla, "Rayuela") {
+		t.Fatalf("la búsqueda no filtró los libros:\n%s", pantalla)
+	}
+
+	casos := []struct {
+		nombre   string
+		teclas   string
+		mensaje  string
+		usuario  int // quién tiene Rayuela después; 0 si nadie
+		renovado int
+	}{
+		{"prestar al primer usuario", "p", "Prestado: Rayuela", ana.ID, 0},
+		{"renovar", "r", "Renovado: Rayuela", ana.ID, 1},
+		{"prestar un libro ya prestado", "p", "Error: ", ana.ID, 1},
+		{"devolver", "d", "Devuelto: Rayuela", 0, 0},
+		// Tab pasa al panel de usuarios y la flecha elige a Carlos
+		{"prestar al segundo usuario", "\t\x1b[Bp", "Prestado: Rayuela", carlos.ID, 0},
+	}
+	for _, caso := range casos {
+		pantalla := teclear(caso.teclas)
+		if !strings.Contains(pantalla, " "+caso.mensaje) {
+			t.Errorf("%s: falta %q en la pantalla:\n%s", caso.nombre, caso.mensaje, pantalla)
+		}
+		if got := prestadoA(); got != caso.usuario {
+			t.Errorf("%s: Rayuela está prestado al usuario %d, se esperaba %d", caso.nombre, got, caso.usuario)
+		}
+		if prestamo := b.buscarPrestamoActivo(rayuela.ID); prestamo != nil && prestamo.Renovaciones != caso.renovado {
+			t.Errorf("%s: %d renovaciones, se esperaban %d", caso.nombre, prestamo.Renovaciones, caso.renovado)
+		}
+	}
+	if pantalla := teclear(""); !strings.Contains(pantalla, "• Rayuela — vence") {
+		t.Errorf("el préstamo de Carlos no aparece entre los activos:\n%s", pantalla)
+	}
+
+	// Ctrl+C sale también mientras se escribe la consulta
+	teclear("/x")
+	if m.Terminado() {
+		t.Fatal("el mostrador terminó antes de tiempo")
+	}
+	teclear("\x03")
+	if !m.Terminado() {
+		t.Error("Ctrl+C no cerró el mostrador")
+	}
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ==========================================
+// WEBHOOKS: AVISOS A SISTEMAS EXTERNOS
+// ==========================================
+
+// Cabeceras que acompañan cada entrega
+const (
+	CabeceraFirma   = "X-Biblioteca-Firma"   // "sha256=<hex>" del cuerpo con el secreto
+	CabeceraEvento  = "X-Biblioteca-Evento"  // tipo de evento
+	CabeceraEntrega = "X-Biblioteca-Entrega" // ID del evento, igual en todos los reintentos
+)
+
+// Webhook es un suscriptor externo de eventos
+type Webhook struct {
+	ID      int
+	URL     string
+	Secreto string
+	Eventos []TipoEvento // vacío: todos los eventos
+}
+
+// EntregaWebhook es un intento de entrega guardado en el registro
+type EntregaWebhook struct {
+	WebhookID int
+	EventoID  string
+	Tipo      TipoEvento
+	Intento   int
+	Estado    int // código HTTP; 0 si no hubo respuesta
+	Error     string
+	Exitosa   bool
+	Fecha     time.Time
+	Duracion  time.Duration
+}
+
+// DespachadorWebhooks envía los eventos de una biblioteca a sus suscriptores
+type DespachadorWebhooks struct {
+	Cliente        *http.Client
+	MaxIntentos    int
+	BackoffInicial time.Duration // se duplica en cada reintento
+	esperar        func(time.Duration)
+
+	mu        sync.Mutex
+	webhooks  []Webhook
+	registro  []EntregaWebhook
+	proximoID int
+	pendiente sync.WaitGroup
+}
+
+// NuevoDespachadorWebhooks crea un despachador y lo suscribe a la biblioteca
+func NuevoDespachadorWebhooks(b *Biblioteca) *DespachadorWebhooks {
+	d := &DespachadorWebhooks{
+		Cliente:        &http.Client{Timeout: 10 * time.Second},
+		MaxIntentos:    5,
+		BackoffInicial: 500 * time.Millisecond,
+		esperar:        time.Sleep,
+		proximoID:      1,
+	}
+	b.Suscribir(func(evento Evento) {
+		for _, webhook := range d.suscritos(evento.Tipo) {
+			d.pendiente.Add(1)
+			go func(w Webhook) {
+				defer d.pendiente.Done()
+				d.Entregar(w, evento)
+			}(webhook)
+		}
+	})
+	return d
+}
+
+// Registrar agrega un suscriptor y retorna su ID
+func (d *DespachadorWebhooks) Registrar(url, secreto string, eventos ...TipoEvento) (int, error) {
+	if url == "" || secreto == "" {
+		return 0, errDatoRequerido("webhook", campoVacio("url", url, "secreto"), "Debe proporcionar URL y secreto")
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	webhook := Webhook{ID: d.proximoID, URL: url, Secreto: secreto, Eventos: eventos}
+	d.webhooks = append(d.webhooks, webhook)
+	d.proximoID++
+	return webhook.ID, nil
+}
+
+// Eliminar quita un suscriptor
+func (d *DespachadorWebhooks) Eliminar(webhookID int) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i := range d.webhooks {
+		if d.webhooks[i].ID == webhookID {
+			d.webhooks = append(d.webhooks[:i], d.webhooks[i+1:]...)
+			return nil
+		}
+	}
+	return nuevoError(CodigoOperacionInvalida, "webhook", webhookID, "id", fmt.Sprint(webhookID),
+		"No existe un webhook con ID '%d'", webhookID)
+}
+
+// Entregar envía un evento a un webhook, reintentando con backoff
+// exponencial ante errores de red, 429 y 5xx. Retorna true si se entregó.
+func (d *DespachadorWebhooks) Entregar(webhook Webhook, evento Evento) bool {
+	cuerpo, err := json.Marshal(evento)
+	if err != nil {
+		d.anotar(EntregaWebhook{WebhookID: webhook.ID, EventoID: evento.ID, Tipo: evento.Tipo,
+			Intento: 1, Error: err.Error(), Fecha: time.Now()})
+		return false
+	}
+	firma := FirmarPayload(webhook.Secreto, cuerpo)
+
+	maxIntentos := max(d.MaxIntentos, 1)
+	espera := d.BackoffInicial
+	for intento := 1; intento <= maxIntentos; intento++ {
+		inicio := time.Now()
+		estado, err := d.enviar(webhook.URL, evento, cuerpo, firma)
+		entrega := EntregaWebhook{
+			WebhookID: webhook.ID,
+			EventoID:  evento.ID,
+			Tipo:      evento.Tipo,
+			Intento:   intento,
+			Estado:    estado,
+			Exitosa:   err == nil && estado >= 200 && estado < 300,
+			Fecha:     inicio,
+			Duracion:  time.Since(inicio),
+		}
+		if err != nil {
+			entrega.Error = err.Error()
+		}
+		d.anotar(entrega)
+
+		if entrega.Exitosa {
+			return true
+		}
+		reintentable := err != nil || estado == http.StatusTooManyRequests || estado >= 500
+		if !reintentable || intento == maxIntentos {
+			return false
+		}
+		d.esperar(espera)
+		espera *= 2
+	}
+	return false
+}
+
+// Esperar bloquea hasta que terminen las entregas en curso
+func (d *DespachadorWebhooks) Esperar() {
+	d.pendiente.Wait()
+}
+
+// Registro retorna una copia del historial de entregas
+func (d *DespachadorWebhooks) Registro() []EntregaWebhook {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]EntregaWebhook(nil), d.registro...)
+}
+
+// FirmarPayload calcula la firma HMAC-SHA256 que el receptor debe verificar
+func FirmarPayload(secreto string, cuerpo []byte) string {
+	mac := hmac.New(sha256.New, []byte(secreto))
+	mac.Write(cuerpo)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerificarFirma compara en tiempo constante la firma recibida
+func VerificarFirma(secreto string, cuerpo []byte, firma string) bool {
+	return hmac.Equal([]byte(FirmarPayload(secreto, cuerpo)), []byte(firma))
+}
+
+func (d *DespachadorWebhooks) enviar(url string, evento Evento, cuerpo []byte, firma string) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(cuerpo))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(CabeceraFirma, firma)
+	req.Header.Set(CabeceraEvento, string(evento.Tipo))
+	req.Header.Set(CabeceraEntrega, evento.ID)
+
+	resp, err := d.Cliente.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
+
+func (d *DespachadorWebhooks) suscritos(tipo TipoEvento) []Webhook {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	suscritos := make([]Webhook, 0)
+	for _, webhook := range d.webhooks {
+		if len(webhook.Eventos) == 0 {
+			suscritos = append(suscritos, webhook)
+			continue
+		}
+		for _, t := range webhook.Eventos {
+			if t == tipo {
+				suscritos = append(suscritos, webhook)
+				break
+			}
+		}
+	}
+	return suscritos
+}
+
+func (d *DespachadorWebhooks) anotar(entrega EntregaWebhook) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.registro = append(d.registro, entrega)
+}
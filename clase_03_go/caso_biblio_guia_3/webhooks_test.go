@@ -0,0 +1,197 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// receptorWebhook es un suscriptor de prueba que responde con los códigos
+// indicados, en orden, y guarda cada petición recibida
+type receptorWebhook struct {
+	mu         sync.Mutex
+	codigos    []int
+	peticiones []peticionWebhook
+}
+
+type peticionWebhook struct {
+	Cabeceras http.Header
+	Cuerpo    []byte
+}
+
+func (r *receptorWebhook) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	cuerpo, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.peticiones = append(r.peticiones, peticionWebhook{Cabeceras: req.Header.Clone(), Cuerpo: cuerpo})
+	codigo := http.StatusOK
+	if len(r.codigos) > 0 {
+		codigo, r.codigos = r.codigos[0], r.codigos[1:]
+	}
+	w.WriteHeader(codigo)
+}
+
+func (r *receptorWebhook) recibidas() []peticionWebhook {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]peticionWebhook(nil), r.peticiones...)
+}
+
+// nuevoDespachadorPrueba crea un despachador sin esperas reales; retorna
+// también las esperas que habría hecho entre intentos
+func nuevoDespachadorPrueba(t *testing.T, maxIntentos int) (*DespachadorWebhooks, *[]time.Duration) {
+	t.Helper()
+	d := NuevoDespachadorWebhooks(NuevaBiblioteca("Biblioteca de Pruebas", ""))
+	d.MaxIntentos = maxIntentos
+	d.BackoffInicial = 100 * time.Millisecond
+	esperas := &[]time.Duration{}
+	d.esperar = func(espera time.Duration) { *esperas = append(*esperas, espera) }
+	return d, esperas
+}
+
+func TestWebhookFirmaYCabeceras(t *testing.T) {
+	receptor := &receptorWebhook{}
+	servidor := httptest.NewServer(receptor)
+	defer servidor.Close()
+
+	b := NuevaBiblioteca("Biblioteca de Pruebas", "")
+	d := NuevoDespachadorWebhooks(b)
+	if _, err := d.Registrar(servidor.URL, "secreto", EventoLibroPrestado); err != nil {
+		t.Fatal(err)
+	}
+	libro, _ := b.AgregarLibro("El Quijote", "Miguel de Cervantes", "978-84-376-0494-7", 863)
+	usuario, _ := b.RegistrarUsuario("Ana", "ana@gmail.com", "")
+	usuario.Activar()
+	if err := b.PrestarLibro(libro.ID, usuario.ID); err != nil {
+		t.Fatal(err)
+	}
+	d.Esperar()
+
+	recibidas := receptor.recibidas()
+	if len(recibidas) != 1 {
+		t.Fatalf("se esperaba solo el evento de préstamo y llegaron %d peticiones", len(recibidas))
+	}
+	peticion := recibidas[0]
+	if !VerificarFirma("secreto", peticion.Cuerpo, peticion.Cabeceras.Get(CabeceraFirma)) {
+		t.Errorf("firma %q no corresponde al cuerpo", peticion.Cabeceras.Get(CabeceraFirma))
+	}
+	if VerificarFirma("otro", peticion.Cuerpo, peticion.Cabeceras.Get(CabeceraFirma)) {
+		t.Error("la firma no debe validar con otro secreto")
+	}
+
+	var evento Evento
+	if err := json.Unmarshal(peticion.Cuerpo, &evento); err != nil {
+		t.Fatal(err)
+	}
+	if evento.Tipo != EventoLibroPrestado || evento.LibroID != libro.ID || evento.UsuarioID != usuario.ID {
+		t.Errorf("evento inesperado: %+v", evento)
+	}
+	if got := peticion.Cabeceras.Get(CabeceraEvento); got != string(EventoLibroPrestado) {
+		t.Errorf("%s = %q", CabeceraEvento, got)
+	}
+	if got := peticion.Cabeceras.Get(CabeceraEntrega); got != evento.ID {
+		t.Errorf("%s = %q, se esperaba %q", CabeceraEntrega, got, evento.ID)
+	}
+	if got := peticion.Cabeceras.Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q", got)
+	}
+}
+
+func TestWebhookReintentos(t *testing.T) {
+	casos := []struct {
+		nombre      string
+		maxIntentos int
+		codigos     []int
+		entregado   bool
+		intentos    int
+		esperas     []time.Duration
+	}{
+		{"exito al primer intento", 5, nil, true, 1, nil},
+		{"reintenta 5xx y 429", 5, []int{503, 429, 200}, true, 3,
+			[]time.Duration{100 * time.Millisecond, 200 * time.Millisecond}},
+		{"4xx no se reintenta", 5, []int{400}, false, 1, nil},
+		{"agota los intentos", 3, []int{500, 500, 500, 200}, false, 3,
+			[]time.Duration{100 * time.Millisecond, 200 * time.Millisecond}},
+		{"sin intentos configurados hace uno y no espera", 0, []int{500}, false, 1, nil},
+	}
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			receptor := &receptorWebhook{codigos: caso.codigos}
+			servidor := httptest.NewServer(receptor)
+			defer servidor.Close()
+
+			d, esperas := nuevoDespachadorPrueba(t, caso.maxIntentos)
+			webhook := Webhook{ID: 7, URL: servidor.URL, Secreto: "secreto"}
+			evento := Evento{ID: "evt_1", Tipo: EventoLibroDevuelto, LibroID: 3}
+			if got := d.Entregar(webhook, evento); got != caso.entregado {
+				t.Fatalf("Entregar = %v, se esperaba %v", got, caso.entregado)
+			}
+
+			recibidas := receptor.recibidas()
+			if len(recibidas) != caso.intentos {
+				t.Fatalf("el receptor recibió %d intentos, se esperaban %d", len(recibidas), caso.intentos)
+			}
+			for _, peticion := range recibidas {
+				if peticion.Cabeceras.Get(CabeceraEntrega) != "evt_1" {
+					t.Errorf("el ID de entrega debe repetirse en cada reintento")
+				}
+			}
+			if len(*esperas) != len(caso.esperas) {
+				t.Fatalf("esperas = %v, se esperaba %v", *esperas, caso.esperas)
+			}
+			for i := range caso.esperas {
+				if (*esperas)[i] != caso.esperas[i] {
+					t.Errorf("espera %d = %v, se esperaba %v", i+1, (*esperas)[i], caso.esperas[i])
+				}
+			}
+
+			registro := d.Registro()
+			if len(registro) != caso.intentos {
+				t.Fatalf("el registro tiene %d entregas, se esperaban %d", len(registro), caso.intentos)
+			}
+			for i, entrega := range registro {
+				if entrega.WebhookID != 7 || entrega.EventoID != "evt_1" || entrega.Tipo != EventoLibroDevuelto {
+					t.Errorf("entrega %d con datos inesperados: %+v", i+1, entrega)
+				}
+				if entrega.Intento != i+1 {
+					t.Errorf("entrega %d con Intento = %d", i+1, entrega.Intento)
+				}
+				codigo := http.StatusOK
+				if i < len(caso.codigos) {
+					codigo = caso.codigos[i]
+				}
+				if entrega.Estado != codigo {
+					t.Errorf("entrega %d con Estado = %d, se esperaba %d", i+1, entrega.Estado, codigo)
+				}
+				ultimaExitosa := caso.entregado && i == len(registro)-1
+				if entrega.Exitosa != ultimaExitosa {
+					t.Errorf("entrega %d con Exitosa = %v", i+1, entrega.Exitosa)
+				}
+			}
+		})
+	}
+}
+
+func TestWebhookErrorDeRed(t *testing.T) {
+	servidor := httptest.NewServer(http.NotFoundHandler())
+	url := servidor.URL
+	servidor.Close()
+
+	d, esperas := nuevoDespachadorPrueba(t, 2)
+	if d.Entregar(Webhook{ID: 1, URL: url, Secreto: "secreto"}, Evento{ID: "evt_1"}) {
+		t.Fatal("no debe entregarse a un servidor cerrado")
+	}
+	registro := d.Registro()
+	if len(registro) != 2 || len(*esperas) != 1 {
+		t.Fatalf("se esperaban 2 intentos y 1 espera; registro %d, esperas %v", len(registro), *esperas)
+	}
+	for _, entrega := range registro {
+		if entrega.Estado != 0 || entrega.Error == "" {
+			t.Errorf("un error de red debe anotarse sin estado y con el error: %+v", entrega)
+		}
+	}
+}
@@ -0,0 +1,545 @@
+package interfaces
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ==========================================
+// ALMACÉN DE REGISTROS COMPARTIDO
+// ==========================================
+
+// FiltroRegistros indica qué registros buscar; los campos vacíos no filtran
+type FiltroRegistros struct {
+	Destinatario string
+	Tipo         TipoNotificacion
+	Estado       EstadoNotificacion
+	Desde        time.Time // incluido
+	Hasta        time.Time // excluido
+}
+
+// Cumple indica si el registro pasa el filtro
+func (f FiltroRegistros) Cumple(r RegistroNotificacion) bool {
+	switch {
+	case f.Destinatario != "" && r.Destinatario != f.Destinatario:
+		return false
+	case f.Tipo != "" && r.Tipo != f.Tipo:
+		return false
+	case f.Estado != "" && r.Estado != f.Estado:
+		return false
+	case !f.Desde.IsZero() && r.Timestamp.Before(f.Desde):
+		return false
+	case !f.Hasta.IsZero() && !r.Timestamp.Before(f.Hasta):
+		return false
+	}
+	return true
+}
+
+// AlmacenRegistros guarda los registros de todos los notificadores. Las
+// implementaciones son seguras para uso concurrente y trabajan con
+// copias: modificar un registro obtenido no cambia el almacén.
+type AlmacenRegistros interface {
+	Guardar(registro RegistroNotificacion) error
+	Obtener(id string) (RegistroNotificacion, error)
+	// Actualizar aplica cambiar al registro sin que otro lo modifique a la
+	// vez; si cambiar retorna error no se guarda nada
+	Actualizar(id string, cambiar func(*RegistroNotificacion) error) (RegistroNotificacion, error)
+	// Buscar retorna los registros que cumplen el filtro, del más antiguo al más nuevo
+	Buscar(filtro FiltroRegistros) ([]RegistroNotificacion, error)
+	// Purgar borra los registros anteriores a la fecha y retorna cuántos borró
+	Purgar(antesDe time.Time) (int, error)
+}
+
+// copiarRegistro evita que el almacén y el llamador compartan el
+// historial o los metadatos
+func copiarRegistro(r RegistroNotificacion) RegistroNotificacion {
+	r.Historial = slices.Clone(r.Historial)
+	r.Metadatos = maps.Clone(r.Metadatos)
+	return r
+}
+
+// ==========================================
+// Almacén en memoria
+// ==========================================
+
+// AlmacenMemoria guarda los registros en un mapa; se pierden al reiniciar
+type AlmacenMemoria struct {
+	mu        sync.RWMutex
+	registros map[string]RegistroNotificacion
+}
+
+// NuevoAlmacenMemoria crea un almacén vacío
+func NuevoAlmacenMemoria() *AlmacenMemoria {
+	return &AlmacenMemoria{registros: make(map[string]RegistroNotificacion)}
+}
+
+func (a *AlmacenMemoria) Guardar(registro RegistroNotificacion) error {
+	if registro.ID == "" {
+		return errors.New("el registro no tiene ID")
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.registros[registro.ID] = copiarRegistro(registro)
+	return nil
+}
+
+func (a *AlmacenMemoria) Obtener(id string) (RegistroNotificacion, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	registro, existe := a.registros[id]
+	if !existe {
+		return RegistroNotificacion{}, ErrNotificacionNoEncontrada
+	}
+	return copiarRegistro(registro), nil
+}
+
+func (a *AlmacenMemoria) Actualizar(id string, cambiar func(*RegistroNotificacion) error) (RegistroNotificacion, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	registro, existe := a.registros[id]
+	if !existe {
+		return RegistroNotificacion{}, ErrNotificacionNoEncontrada
+	}
+	registro = copiarRegistro(registro)
+	if err := cambiar(&registro); err != nil {
+		return RegistroNotificacion{}, err
+	}
+	registro.ID = id // el ID no se puede cambiar
+	a.registros[id] = copiarRegistro(registro)
+	return registro, nil
+}
+
+func (a *AlmacenMemoria) Buscar(filtro FiltroRegistros) ([]RegistroNotificacion, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	var encontrados []RegistroNotificacion
+	for _, registro := range a.registros {
+		if filtro.Cumple(registro) {
+			encontrados = append(encontrados, copiarRegistro(registro))
+		}
+	}
+	slices.SortFunc(encontrados, func(x, y RegistroNotificacion) int {
+		if c := x.Timestamp.Compare(y.Timestamp); c != 0 {
+			return c
+		}
+		return cmp.Compare(x.ID, y.ID)
+	})
+	return encontrados, nil
+}
+
+func (a *AlmacenMemoria) Purgar(antesDe time.Time) (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	borrados := 0
+	for id, registro := range a.registros {
+		if registro.Timestamp.Before(antesDe) {
+			delete(a.registros, id)
+			borrados++
+		}
+	}
+	return borrados, nil
+}
+
+// ==========================================
+// Almacén en archivo
+// ==========================================
+
+// AlmacenArchivo guarda los registros en un archivo JSON Lines: cada
+// cambio agrega una línea con el registro completo y al leer gana la
+// última. El archivo se compacta al abrirlo y al purgar.
+type AlmacenArchivo struct {
+	ruta    string
+	memoria *AlmacenMemoria
+
+	mu      sync.Mutex // ordena las escrituras en el archivo
+	archivo *os.File
+}
+
+// registroArchivo es la forma en disco de RegistroNotificacion, con los
+// errores como texto
+type registroArchivo struct {
+	ID           string             `json:"id"`
+	Tipo         TipoNotificacion   `json:"tipo"`
+	Destinatario string             `json:"destinatario"`
+	Mensaje      string             `json:"mensaje"`
+	CuerpoHTML   string             `json:"cuerpo_html,omitempty"`
+	Estado       EstadoNotificacion `json:"estado"`
+	Timestamp    time.Time          `json:"timestamp"`
+	Intentos     int                `json:"intentos"`
+	Error        string             `json:"error,omitempty"`
+	Historial    []intentoArchivo   `json:"historial,omitempty"`
+	Prioridad    Prioridad          `json:"prioridad,omitempty"`
+	Metadatos    map[string]string  `json:"metadatos,omitempty"`
+}
+
+type intentoArchivo struct {
+	Numero   int           `json:"numero"`
+	Inicio   time.Time     `json:"inicio"`
+	Duracion time.Duration `json:"duracion"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// AbrirAlmacenArchivo carga los registros de la ruta, creando el archivo
+// si no existe
+func AbrirAlmacenArchivo(ruta string) (*AlmacenArchivo, error) {
+	a := &AlmacenArchivo{ruta: ruta, memoria: NuevoAlmacenMemoria()}
+	if err := a.cargar(); err != nil {
+		return nil, err
+	}
+	if err := a.compactar(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *AlmacenArchivo) Guardar(registro RegistroNotificacion) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := a.memoria.Guardar(registro); err != nil {
+		return err
+	}
+	return a.escribir(registro)
+}
+
+func (a *AlmacenArchivo) Obtener(id string) (RegistroNotificacion, error) {
+	return a.memoria.Obtener(id)
+}
+
+func (a *AlmacenArchivo) Actualizar(id string, cambiar func(*RegistroNotificacion) error) (RegistroNotificacion, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	registro, err := a.memoria.Actualizar(id, cambiar)
+	if err != nil {
+		return RegistroNotificacion{}, err
+	}
+	return registro, a.escribir(registro)
+}
+
+func (a *AlmacenArchivo) Buscar(filtro FiltroRegistros) ([]RegistroNotificacion, error) {
+	return a.memoria.Buscar(filtro)
+}
+
+func (a *AlmacenArchivo) Purgar(antesDe time.Time) (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	borrados, _ := a.memoria.Purgar(antesDe)
+	if borrados == 0 {
+		return 0, nil
+	}
+	return borrados, a.compactar()
+}
+
+// Cerrar libera el archivo; el almacén no debe usarse después
+func (a *AlmacenArchivo) Cerrar() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.archivo == nil {
+		return nil
+	}
+	err := a.archivo.Close()
+	a.archivo = nil
+	return err
+}
+
+func (a *AlmacenArchivo) escribir(registro RegistroNotificacion) error {
+	if a.archivo == nil {
+		return errors.New("almacén cerrado")
+	}
+	linea, err := json.Marshal(aArchivo(registro))
+	if err != nil {
+		return err
+	}
+	if _, err := a.archivo.Write(append(linea, '\n')); err != nil {
+		return fmt.Errorf("no se pudo guardar el registro %s: %w", registro.ID, err)
+	}
+	return nil
+}
+
+func (a *AlmacenArchivo) cargar() error {
+	f, err := os.Open(a.ruta)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	lector := bufio.NewScanner(f)
+	lector.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for n := 1; lector.Scan(); n++ {
+		if len(lector.Bytes()) == 0 {
+			continue
+		}
+		var r registroArchivo
+		if err := json.Unmarshal(lector.Bytes(), &r); err != nil {
+			return fmt.Errorf("%s línea %d: %w", a.ruta, n, err)
+		}
+		a.memoria.registros[r.ID] = deArchivo(r)
+	}
+	return lector.Err()
+}
+
+// compactar reescribe el archivo con una línea por registro y lo
+// reemplaza de forma atómica
+func (a *AlmacenArchivo) compactar() error {
+	registros, _ := a.memoria.Buscar(FiltroRegistros{})
+	temporal, err := os.CreateTemp(filepath.Dir(a.ruta), filepath.Base(a.ruta)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temporal.Name())
+
+	w := bufio.NewWriter(temporal)
+	codificador := json.NewEncoder(w)
+	for _, registro := range registros {
+		if err := codificador.Encode(aArchivo(registro)); err != nil {
+			temporal.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		temporal.Close()
+		return err
+	}
+	if err := temporal.Sync(); err != nil {
+		temporal.Close()
+		return err
+	}
+	if err := temporal.Close(); err != nil {
+		return err
+	}
+	// Si el reemplazo falla, el manejador anterior sigue sirviendo
+	if err := os.Rename(temporal.Name(), a.ruta); err != nil {
+		return err
+	}
+	nuevo, err := os.OpenFile(a.ruta, os.O_WRONLY|os.O_APPEND, 0o644)
+	if a.archivo != nil {
+		// Apunta al archivo reemplazado: lo que se escriba ahí se perdería
+		a.archivo.Close()
+	}
+	a.archivo = nuevo // nil si no se pudo abrir; escribir lo reporta
+	return err
+}
+
+func aArchivo(r RegistroNotificacion) registroArchivo {
+	salida := registroArchivo{
+		ID:           r.ID,
+		Tipo:         r.Tipo,
+		Destinatario: r.Destinatario,
+		Mensaje:      r.Mensaje,
+		CuerpoHTML:   r.CuerpoHTML,
+		Estado:       r.Estado,
+		Timestamp:    r.Timestamp,
+		Intentos:     r.Intentos,
+		Error:        textoError(r.Error),
+		Prioridad:    r.Prioridad,
+		Metadatos:    r.Metadatos,
+	}
+	for _, intento := range r.Historial {
+		salida.Historial = append(salida.Historial, intentoArchivo{
+			Numero: intento.Numero, Inicio: intento.Inicio, Duracion: intento.Duracion, Error: textoError(intento.Error),
+		})
+	}
+	return salida
+}
+
+func deArchivo(r registroArchivo) RegistroNotificacion {
+	salida := RegistroNotificacion{
+		ID:           r.ID,
+		Tipo:         r.Tipo,
+		Destinatario: r.Destinatario,
+		Mensaje:      r.Mensaje,
+		CuerpoHTML:   r.CuerpoHTML,
+		Estado:       r.Estado,
+		Timestamp:    r.Timestamp,
+		Intentos:     r.Intentos,
+		Error:        errorDeTexto(r.Error),
+		Prioridad:    r.Prioridad,
+		Metadatos:    r.Metadatos,
+	}
+	for _, intento := range r.Historial {
+		salida.Historial = append(salida.Historial, IntentoEnvio{
+			Numero: intento.Numero, Inicio: intento.Inicio, Duracion: intento.Duracion, Error: errorDeTexto(intento.Error),
+		})
+	}
+	return salida
+}
+
+func textoError(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func errorDeTexto(texto string) error {
+	if texto == "" {
+		return nil
+	}
+	return errors.New(texto)
+}
+
+// ProgramarRetencion purga cada intervalo los registros con más de
+// retener de antigüedad. detener espera a que termine la purga en curso.
+func ProgramarRetencion(almacen AlmacenRegistros, retener, intervalo time.Duration, alError func(error)) (detener func()) {
+	fin := make(chan struct{})
+	listo := make(chan struct{})
+	go func() {
+		defer close(listo)
+		ticker := time.NewTicker(intervalo)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-fin:
+				return
+			case <-ticker.C:
+				if _, err := almacen.Purgar(time.Now().Add(-retener)); err != nil && alError != nil {
+					alError(err)
+				}
+			}
+		}
+	}()
+	var unaVez sync.Once
+	return func() {
+		unaVez.Do(func() { close(fin) })
+		<-listo
+	}
+}
+
+// ==========================================
+// Rastreo común a los notificadores
+// ==========================================
+
+// rastreo se incluye en cada notificador: guarda sus registros en el
+// almacén, aplica las transiciones de estado e implementa Rastreador y
+// ActualizadorEstado una sola vez para todos
+type rastreo struct {
+	tipo      TipoNotificacion
+	almacen   AlmacenRegistros
+	secuencia atomic.Uint64 // evita IDs repetidos entre envíos simultáneos
+	observadoresEstado
+}
+
+func nuevoRastreo(tipo TipoNotificacion) rastreo {
+	return rastreo{tipo: tipo, almacen: NuevoAlmacenMemoria()}
+}
+
+// ConfigurarAlmacen reemplaza el almacén en memoria que trae el
+// notificador, por ejemplo para compartir uno entre todos los canales
+func (r *rastreo) ConfigurarAlmacen(almacen AlmacenRegistros) {
+	r.almacen = almacen
+}
+
+// Almacen retorna el almacén donde el notificador guarda sus registros
+func (r *rastreo) Almacen() AlmacenRegistros {
+	return r.almacen
+}
+
+// nuevoID arma el ID de un envío: canal, instante y secuencia
+func (r *rastreo) nuevoID() string {
+	return fmt.Sprintf("%s_%d_%d", r.tipo, time.Now().UnixNano(), r.secuencia.Add(1))
+}
+
+// registrar guarda el registro recién creado
+func (r *rastreo) registrar(registro *RegistroNotificacion) error {
+	return r.almacen.Guardar(*registro)
+}
+
+// finalizar guarda el resultado del envío (error, intentos e historial)
+// y pasa el registro a su nuevo estado
+func (r *rastreo) finalizar(registro *RegistroNotificacion, nuevo EstadoNotificacion, detalle string) error {
+	var cambio CambioEstado
+	var cambiado bool
+	_, err := r.almacen.Actualizar(registro.ID, func(guardado *RegistroNotificacion) error {
+		guardado.Error = registro.Error
+		guardado.Intentos = registro.Intentos
+		guardado.Historial = registro.Historial
+		var err error
+		cambio, cambiado, err = aplicarEstado(guardado, nuevo, detalle)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	registro.Estado = nuevo
+	if cambiado {
+		r.avisar(cambio)
+	}
+	return nil
+}
+
+// ObtenerEstado implementa Rastreador
+func (r *rastreo) ObtenerEstado(id string) (string, error) {
+	registro, err := r.almacen.Obtener(id)
+	if err != nil || registro.Tipo != r.tipo {
+		return "", ErrNotificacionNoEncontrada
+	}
+	return string(registro.Estado), nil
+}
+
+// ActualizarEstado aplica un acuse del proveedor (entregada, rebotada, leida)
+func (r *rastreo) ActualizarEstado(id string, nuevo EstadoNotificacion, detalle string) error {
+	var cambio CambioEstado
+	var cambiado bool
+	_, err := r.almacen.Actualizar(id, func(registro *RegistroNotificacion) error {
+		if registro.Tipo != r.tipo {
+			return ErrNotificacionNoEncontrada
+		}
+		var err error
+		cambio, cambiado, err = aplicarEstado(registro, nuevo, detalle)
+		return err
+	})
+	if cambiado && err == nil {
+		r.avisar(cambio)
+	}
+	return err
+}
+
+// ObtenerEstadisticas implementa Rastreador con los registros de este
+// canal; si el almacén es compartido no cuenta los de otros canales
+func (r *rastreo) ObtenerEstadisticas() map[string]int {
+	registros, _ := r.almacen.Buscar(FiltroRegistros{Tipo: r.tipo})
+	return contarEstados(registros)
+}
+
+// contarEstados arma las estadísticas que retorna Rastreador
+func contarEstados(registros []RegistroNotificacion) map[string]int {
+	stats := map[string]int{
+		"total":      0,
+		"enviados":   0,
+		"fallidos":   0,
+		"pendientes": 0,
+		"entregadas": 0,
+		"rebotadas":  0,
+		"leidas":     0,
+	}
+	for _, registro := range registros {
+		stats["total"]++
+		switch registro.Estado {
+		case Enviado:
+			stats["enviados"]++
+		case Fallida:
+			stats["fallidos"]++
+		case Pendiente:
+			stats["pendientes"]++
+		case Entregada:
+			stats["entregadas"]++
+		case Rebotada:
+			stats["rebotadas"]++
+		case Leida:
+			stats["leidas"]++
+		}
+	}
+	return stats
+}
@@ -0,0 +1,91 @@
+package interfaces
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAlmacenArchivoPurgarYReabrir(t *testing.T) {
+	ruta := filepath.Join(t.TempDir(), "registros.jsonl")
+	almacen, err := AbrirAlmacenArchivo(ruta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ahora := time.Now()
+	viejo := RegistroNotificacion{ID: "viejo", Tipo: Email, Estado: Enviado, Timestamp: ahora.Add(-48 * time.Hour)}
+	reciente := RegistroNotificacion{ID: "reciente", Tipo: SMS, Estado: Pendiente, Timestamp: ahora}
+	for _, registro := range []RegistroNotificacion{viejo, reciente} {
+		if err := almacen.Guardar(registro); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if borrados, err := almacen.Purgar(ahora.Add(-24 * time.Hour)); err != nil || borrados != 1 {
+		t.Fatalf("Purgar = %d, %v; se esperaba 1 borrado", borrados, err)
+	}
+	// Tras compactar se sigue escribiendo en el archivo nuevo, no en el reemplazado
+	if _, err := almacen.Actualizar("reciente", func(r *RegistroNotificacion) error {
+		r.Estado = Enviado
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := almacen.Guardar(RegistroNotificacion{ID: "nuevo", Tipo: Slack, Timestamp: ahora}); err != nil {
+		t.Fatal(err)
+	}
+	if err := almacen.Cerrar(); err != nil {
+		t.Fatal(err)
+	}
+
+	reabierto, err := AbrirAlmacenArchivo(ruta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reabierto.Cerrar()
+	if _, err := reabierto.Obtener("viejo"); !errors.Is(err, ErrNotificacionNoEncontrada) {
+		t.Errorf("el registro purgado volvió al reabrir: %v", err)
+	}
+	if registro, err := reabierto.Obtener("reciente"); err != nil || registro.Estado != Enviado {
+		t.Errorf("reciente = %+v, %v; se esperaba estado %s", registro, err, Enviado)
+	}
+	if _, err := reabierto.Obtener("nuevo"); err != nil {
+		t.Errorf("se perdió lo escrito después de compactar: %v", err)
+	}
+	temporales, _ := filepath.Glob(ruta + ".tmp*")
+	if len(temporales) != 0 {
+		t.Errorf("quedaron archivos temporales: %v", temporales)
+	}
+}
+
+func TestAlmacenArchivoCompactarFallido(t *testing.T) {
+	dir := t.TempDir()
+	ruta := filepath.Join(dir, "registros.jsonl")
+	almacen, err := AbrirAlmacenArchivo(ruta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer almacen.Cerrar()
+	ahora := time.Now()
+	almacen.Guardar(RegistroNotificacion{ID: "viejo", Tipo: Email, Timestamp: ahora.Add(-48 * time.Hour)})
+
+	// Una carpeta en el lugar del archivo hace fallar el reemplazo
+	if err := os.Rename(ruta, ruta+".aparte"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(ruta, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(ruta, "ocupado"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := almacen.Purgar(ahora); err == nil {
+		t.Fatal("se esperaba un error al compactar")
+	}
+	// El manejador anterior sigue abierto: guardar no debe fallar
+	if err := almacen.Guardar(RegistroNotificacion{ID: "otro", Tipo: Email, Timestamp: ahora}); err != nil {
+		t.Errorf("Guardar tras una compactación fallida: %v", err)
+	}
+}
@@ -0,0 +1,344 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+)
+
+// ==========================================
+// DESPACHADOR MULTICANAL
+// ==========================================
+
+// PerfilDestinatario reúne los datos de contacto de una persona
+type PerfilDestinatario struct {
+	Nombre            string
+	Email             string
+	Telefono          string
+	Slack             string // "@U024BE7LH" o "#canal"
+	TokensDispositivo []string
+	Preferencias      []TipoNotificacion // canales en orden de preferencia; vacío: el de la política
+	Idioma            string             // para elegir la variante de la plantilla ("es-PE")
+}
+
+// ModoRuteo indica cómo se usan los canales
+type ModoRuteo int
+
+const (
+	// RuteoRespaldo prueba los canales en orden hasta que uno funcione
+	RuteoRespaldo ModoRuteo = iota
+	// RuteoDifusion envía por todos los canales a la vez
+	RuteoDifusion
+)
+
+// PoliticaRuteo dice qué canales usar y cómo
+type PoliticaRuteo struct {
+	Modo    ModoRuteo
+	Canales []TipoNotificacion // orden por defecto si el perfil no tiene preferencias
+}
+
+// ResultadoCanal es lo que pasó en un canal para un destinatario
+type ResultadoCanal struct {
+	Canal        TipoNotificacion
+	Destinatario string
+	ID           string // para consultar ObtenerEstado
+	Error        error
+	Omitido      bool // no se intentó: no hay datos de contacto o no hay notificador
+}
+
+// Exitoso indica si el envío por este canal funcionó
+func (r ResultadoCanal) Exitoso() bool {
+	return !r.Omitido && r.Error == nil
+}
+
+// ResultadoDespacho reúne el resultado de todos los canales
+type ResultadoDespacho struct {
+	Resultados []ResultadoCanal
+}
+
+// Exitoso indica si al menos un canal entregó el mensaje
+func (r ResultadoDespacho) Exitoso() bool {
+	for _, resultado := range r.Resultados {
+		if resultado.Exitoso() {
+			return true
+		}
+	}
+	return false
+}
+
+// Despachador elige notificadores según el perfil y la política
+type Despachador struct {
+	mu         sync.Mutex
+	canales    map[TipoNotificacion]NotificadorV2
+	stats      map[string]int
+	plantillas *CatalogoPlantillas
+}
+
+// NuevoDespachador crea un despachador sin canales
+func NuevoDespachador() *Despachador {
+	return &Despachador{
+		canales: make(map[TipoNotificacion]NotificadorV2),
+		stats:   make(map[string]int),
+	}
+}
+
+// Registrar asocia un notificador a un canal
+func (d *Despachador) Registrar(canal TipoNotificacion, notificador NotificadorV2) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.canales[canal] = notificador
+}
+
+// ConfigurarPlantillas define el catálogo que usa DespacharPlantilla
+func (d *Despachador) ConfigurarPlantillas(catalogo *CatalogoPlantillas) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.plantillas = catalogo
+}
+
+// Despachar envía el mensaje según la política. Retorna error solo si
+// ningún canal pudo entregarlo; el detalle de cada canal va en el resultado.
+func (d *Despachador) Despachar(ctx context.Context, perfil PerfilDestinatario, mensaje Mensaje, politica PoliticaRuteo) (ResultadoDespacho, error) {
+	return d.despachar(ctx, perfil, func(TipoNotificacion) (Mensaje, error) { return mensaje, nil }, politica)
+}
+
+// DespacharPlantilla es como Despachar, pero arma el mensaje de cada
+// canal con su variante de la plantilla en el idioma del perfil
+func (d *Despachador) DespacharPlantilla(ctx context.Context, perfil PerfilDestinatario, plantilla string, datos any, politica PoliticaRuteo) (ResultadoDespacho, error) {
+	d.mu.Lock()
+	catalogo := d.plantillas
+	d.mu.Unlock()
+	if catalogo == nil {
+		return ResultadoDespacho{}, errors.New("no hay catálogo de plantillas configurado")
+	}
+	return d.despachar(ctx, perfil, func(canal TipoNotificacion) (Mensaje, error) {
+		return catalogo.Renderizar(plantilla, canal, perfil.Idioma, datos)
+	}, politica)
+}
+
+// armarMensaje retorna el mensaje que corresponde a un canal
+type armarMensaje func(TipoNotificacion) (Mensaje, error)
+
+func (d *Despachador) despachar(ctx context.Context, perfil PerfilDestinatario, mensaje armarMensaje, politica PoliticaRuteo) (ResultadoDespacho, error) {
+	orden := perfil.Preferencias
+	if len(orden) == 0 {
+		orden = politica.Canales
+	}
+	if len(orden) == 0 {
+		return ResultadoDespacho{}, errors.New("no hay canales para despachar")
+	}
+
+	var resultado ResultadoDespacho
+	switch politica.Modo {
+	case RuteoDifusion:
+		resultado = d.difundir(ctx, perfil, mensaje, orden)
+	default:
+		resultado = d.conRespaldo(ctx, perfil, mensaje, orden)
+	}
+	d.anotar(politica.Modo, resultado)
+
+	if !resultado.Exitoso() {
+		return resultado, fmt.Errorf("ningún canal pudo notificar a %s: %w", perfil.Nombre, resultado.errores())
+	}
+	return resultado, nil
+}
+
+// conRespaldo prueba cada canal en orden y se detiene en el primero que funciona
+func (d *Despachador) conRespaldo(ctx context.Context, perfil PerfilDestinatario, mensaje armarMensaje, orden []TipoNotificacion) ResultadoDespacho {
+	var resultado ResultadoDespacho
+	for _, canal := range orden {
+		if ctx.Err() != nil {
+			break
+		}
+		parciales := d.enviarPorCanal(ctx, canal, perfil, mensaje)
+		resultado.Resultados = append(resultado.Resultados, parciales...)
+		if (ResultadoDespacho{Resultados: parciales}).Exitoso() {
+			break
+		}
+	}
+	return resultado
+}
+
+// difundir envía por todos los canales a la vez. Los notificadores son
+// seguros para uso concurrente (también los usan otros Despachar en
+// paralelo); dentro de un canal los envíos van en serie para no mandarle
+// al proveedor una ráfaga por cada token del perfil.
+func (d *Despachador) difundir(ctx context.Context, perfil PerfilDestinatario, mensaje armarMensaje, orden []TipoNotificacion) ResultadoDespacho {
+	parciales := make([][]ResultadoCanal, len(orden))
+	var wg sync.WaitGroup
+	for i, canal := range orden {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			parciales[i] = d.enviarPorCanal(ctx, canal, perfil, mensaje)
+		}()
+	}
+	wg.Wait()
+
+	var resultado ResultadoDespacho
+	for _, p := range parciales {
+		resultado.Resultados = append(resultado.Resultados, p...)
+	}
+	return resultado
+}
+
+// enviarPorCanal envía a cada dirección del perfil para ese canal
+// (varios tokens en push, una sola dirección en el resto)
+func (d *Despachador) enviarPorCanal(ctx context.Context, canal TipoNotificacion, perfil PerfilDestinatario, armar armarMensaje) []ResultadoCanal {
+	d.mu.Lock()
+	notificador, ok := d.canales[canal]
+	d.mu.Unlock()
+	if !ok {
+		return []ResultadoCanal{{Canal: canal, Omitido: true, Error: fmt.Errorf("no hay notificador para %s", canal)}}
+	}
+	destinatarios := perfil.direcciones(canal)
+	if len(destinatarios) == 0 {
+		return []ResultadoCanal{{Canal: canal, Omitido: true, Error: fmt.Errorf("el perfil no tiene datos para %s", canal)}}
+	}
+	mensaje, err := armar(canal)
+	if err != nil {
+		return []ResultadoCanal{{Canal: canal, Error: err}}
+	}
+
+	resultados := make([]ResultadoCanal, 0, len(destinatarios))
+	for _, destinatario := range destinatarios {
+		id, err := notificador.Enviar(ctx, destinatario, mensaje)
+		resultados = append(resultados, ResultadoCanal{Canal: canal, Destinatario: destinatario, ID: id, Error: err})
+	}
+	return resultados
+}
+
+// direcciones retorna los destinatarios del perfil para un canal
+func (p PerfilDestinatario) direcciones(canal TipoNotificacion) []string {
+	var direccion string
+	switch canal {
+	case Email:
+		direccion = p.Email
+	case SMS:
+		direccion = p.Telefono
+	case Slack:
+		direccion = p.Slack
+	case Push:
+		return p.TokensDispositivo
+	}
+	if strings.TrimSpace(direccion) == "" {
+		return nil
+	}
+	return []string{direccion}
+}
+
+func (r ResultadoDespacho) errores() error {
+	errs := make([]error, 0, len(r.Resultados))
+	for _, resultado := range r.Resultados {
+		if resultado.Error != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", resultado.Canal, resultado.Error))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (d *Despachador) anotar(modo ModoRuteo, resultado ResultadoDespacho) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.stats["despachos"]++
+	if !resultado.Exitoso() {
+		d.stats["despachos_fallidos"]++
+	}
+	if modo == RuteoRespaldo {
+		// Varios tokens de push son un solo canal; el respaldo se usó si
+		// se intentó más de un canal
+		intentados := make(map[TipoNotificacion]bool)
+		for _, r := range resultado.Resultados {
+			if !r.Omitido {
+				intentados[r.Canal] = true
+			}
+		}
+		if len(intentados) > 1 {
+			d.stats["respaldos_usados"]++
+		}
+	}
+}
+
+// ==========================================
+// Rastreador sobre todos los canales
+// ==========================================
+
+// notificadores retorna una copia de los canales registrados, para
+// recorrerlos sin tener el candado tomado
+func (d *Despachador) notificadores() map[TipoNotificacion]NotificadorV2 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return maps.Clone(d.canales)
+}
+
+// ObtenerEstado pregunta a cada canal por el ID. No se guarda qué canal
+// emitió cada ID: el almacén de cada notificador es la única fuente, así
+// lo que éste purga deja de encontrarse también aquí.
+func (d *Despachador) ObtenerEstado(id string) (string, error) {
+	for _, notificador := range d.notificadores() {
+		rastreador, ok := notificador.(Rastreador)
+		if !ok {
+			continue
+		}
+		estado, err := rastreador.ObtenerEstado(id)
+		if !errors.Is(err, ErrNotificacionNoEncontrada) {
+			return estado, err
+		}
+	}
+	return "", ErrNotificacionNoEncontrada
+}
+
+// ActualizarEstado pasa el acuse al notificador que emitió el ID, así un
+// solo ReceptorAcuses sirve para todos los canales
+func (d *Despachador) ActualizarEstado(id string, nuevo EstadoNotificacion, detalle string) error {
+	for _, notificador := range d.notificadores() {
+		actualizador, ok := notificador.(ActualizadorEstado)
+		if !ok {
+			continue
+		}
+		err := actualizador.ActualizarEstado(id, nuevo, detalle)
+		if !errors.Is(err, ErrNotificacionNoEncontrada) {
+			return err
+		}
+	}
+	return ErrNotificacionNoEncontrada
+}
+
+// ObtenerEstadisticas suma las estadísticas de todos los canales; además
+// de los totales incluye el detalle por canal ("email.enviados", ...) y
+// los contadores propios del despachador
+func (d *Despachador) ObtenerEstadisticas() map[string]int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	stats := map[string]int{
+		"total":      0,
+		"enviados":   0,
+		"fallidos":   0,
+		"pendientes": 0,
+		"entregadas": 0,
+		"rebotadas":  0,
+		"leidas":     0,
+	}
+	for canal, notificador := range d.canales {
+		rastreador, ok := notificador.(Rastreador)
+		if !ok {
+			continue
+		}
+		for clave, valor := range rastreador.ObtenerEstadisticas() {
+			stats[clave] += valor
+			stats[string(canal)+"."+clave] = valor
+		}
+	}
+	for clave, valor := range d.stats {
+		stats[clave] = valor
+	}
+	return stats
+}
+
+var (
+	_ Rastreador         = (*Despachador)(nil)
+	_ ActualizadorEstado = (*Despachador)(nil)
+)
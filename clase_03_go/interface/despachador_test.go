@@ -0,0 +1,134 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// despachadorPrueba registra Slack y Push contra servidores httptest
+func despachadorPrueba(t *testing.T, codigosSlack, codigosPush []int) (*Despachador, *servidorPrueba, *servidorPrueba) {
+	t.Helper()
+	receptorSlack, urlSlack := levantarServidor(t, codigosSlack...)
+	receptorPush, urlPush := levantarServidor(t, codigosPush...)
+	slack := NuevoSlackNotificador(urlSlack, "#general")
+	slack.reintentos = motorSinEsperas()
+	push := NuevoPushNotificador(urlPush, "clave", "Biblioteca")
+	push.reintentos = motorSinEsperas()
+
+	d := NuevoDespachador()
+	d.Registrar(Slack, slack)
+	d.Registrar(Push, push)
+	return d, receptorSlack, receptorPush
+}
+
+var perfilPrueba = PerfilDestinatario{
+	Nombre:            "Ana",
+	Slack:             "@U024BE7LH",
+	TokensDispositivo: []string{tokenPrueba, tokenPrueba + "_tableta"},
+}
+
+func TestDespachadorRespaldosUsados(t *testing.T) {
+	casos := []struct {
+		nombre     string
+		canales    []TipoNotificacion
+		codigoPush int
+		respaldos  int
+	}{
+		{"varios tokens en el primer canal no son respaldo", []TipoNotificacion{Push, Slack}, http.StatusOK, 0},
+		{"el primer canal falla y responde el segundo", []TipoNotificacion{Push, Slack}, http.StatusGone, 1},
+		{"un canal omitido no cuenta", []TipoNotificacion{Email, Slack}, http.StatusOK, 0},
+	}
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			d, _, _ := despachadorPrueba(t, nil, []int{caso.codigoPush, caso.codigoPush})
+			politica := PoliticaRuteo{Modo: RuteoRespaldo, Canales: caso.canales}
+			if _, err := d.Despachar(context.Background(), perfilPrueba, Mensaje{Cuerpo: "hola"}, politica); err != nil {
+				t.Fatal(err)
+			}
+			if got := d.ObtenerEstadisticas()["respaldos_usados"]; got != caso.respaldos {
+				t.Errorf("respaldos_usados = %d, se esperaba %d", got, caso.respaldos)
+			}
+		})
+	}
+}
+
+func TestDespachadorConcurrente(t *testing.T) {
+	d, receptorSlack, receptorPush := despachadorPrueba(t, nil, nil)
+	politica := PoliticaRuteo{Modo: RuteoDifusion, Canales: []TipoNotificacion{Slack, Push}}
+
+	const despachos = 20
+	resultados := make([]ResultadoDespacho, despachos)
+	var wg sync.WaitGroup
+	for i := range despachos {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resultado, err := d.Despachar(context.Background(), perfilPrueba, Mensaje{Cuerpo: "hola"}, politica)
+			if err != nil {
+				t.Error(err)
+			}
+			resultados[i] = resultado
+		}()
+	}
+	wg.Wait()
+
+	ids := make(map[string]bool)
+	for _, resultado := range resultados {
+		for _, r := range resultado.Resultados {
+			if ids[r.ID] {
+				t.Fatalf("ID repetido entre envíos simultáneos: %s", r.ID)
+			}
+			ids[r.ID] = true
+			if estado, err := d.ObtenerEstado(r.ID); err != nil || estado != string(Enviado) {
+				t.Errorf("%s: estado %q, error %v", r.ID, estado, err)
+			}
+		}
+	}
+	if len(ids) != despachos*3 {
+		t.Errorf("%d IDs, se esperaban %d", len(ids), despachos*3)
+	}
+	if got := len(receptorSlack.recibidas()); got != despachos {
+		t.Errorf("Slack recibió %d mensajes, se esperaban %d", got, despachos)
+	}
+	if got := len(receptorPush.recibidas()); got != despachos*2 {
+		t.Errorf("Push recibió %d mensajes, se esperaban %d", got, despachos*2)
+	}
+	if stats := d.ObtenerEstadisticas(); stats["total"] != despachos*3 || stats["despachos"] != despachos {
+		t.Errorf("estadísticas inesperadas: %v", stats)
+	}
+}
+
+func TestDespachadorEstadoTrasPurgar(t *testing.T) {
+	_, url := levantarServidor(t)
+	slack := NuevoSlackNotificador(url, "#general")
+	d := NuevoDespachador()
+	d.Registrar(Slack, slack)
+
+	politica := PoliticaRuteo{Canales: []TipoNotificacion{Slack}}
+	resultado, err := d.Despachar(context.Background(), perfilPrueba, Mensaje{Cuerpo: "hola"}, politica)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := resultado.Resultados[0].ID
+	if err := d.ActualizarEstado(id, Entregada, ""); err != nil {
+		t.Fatal(err)
+	}
+	if estado, _ := d.ObtenerEstado(id); estado != string(Entregada) {
+		t.Fatalf("estado %q, se esperaba %q", estado, Entregada)
+	}
+
+	// Lo que purga el almacén del notificador deja de existir también para el despachador
+	if _, err := slack.Almacen().Purgar(time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.ObtenerEstado(id); !errors.Is(err, ErrNotificacionNoEncontrada) {
+		t.Errorf("ObtenerEstado tras purgar: %v", err)
+	}
+	if err := d.ActualizarEstado(id, Leida, ""); !errors.Is(err, ErrNotificacionNoEncontrada) {
+		t.Errorf("ActualizarEstado tras purgar: %v", err)
+	}
+}
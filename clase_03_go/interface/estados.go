@@ -0,0 +1,232 @@
+package interfaces
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ==========================================
+// CICLO DE VIDA DEL ESTADO DE UNA NOTIFICACIÓN
+// ==========================================
+
+// transiciones permitidas:
+//
+//	pendiente → enviado → entregada → leida
+//	    ↓          ↓  ↘
+//	 fallida    fallida  rebotada
+var transiciones = map[EstadoNotificacion][]EstadoNotificacion{
+	Pendiente: {Enviado, Fallida},
+	Enviado:   {Entregada, Fallida, Rebotada, Leida}, // algunos proveedores avisan la lectura sin la entrega
+	Entregada: {Leida},
+}
+
+// PuedePasarA indica si la transición está permitida
+func (e EstadoNotificacion) PuedePasarA(nuevo EstadoNotificacion) bool {
+	for _, permitido := range transiciones[e] {
+		if permitido == nuevo {
+			return true
+		}
+	}
+	return false
+}
+
+// EsFinal indica si el estado ya no puede cambiar
+func (e EstadoNotificacion) EsFinal() bool {
+	return len(transiciones[e]) == 0
+}
+
+// ErrTransicionInvalida se retorna al pedir un cambio no permitido
+var ErrTransicionInvalida = errors.New("transición de estado no permitida")
+
+// ErrNotificacionNoEncontrada se retorna si el ID no existe
+var ErrNotificacionNoEncontrada = errors.New("Notificacion no encontrada")
+
+// CambioEstado describe una transición ya aplicada
+type CambioEstado struct {
+	ID       string
+	Tipo     TipoNotificacion
+	Anterior EstadoNotificacion
+	Nuevo    EstadoNotificacion
+	Detalle  string // motivo del rebote, proveedor que confirmó, etc.
+	Fecha    time.Time
+}
+
+// ManejadorCambioEstado recibe cada cambio de estado
+type ManejadorCambioEstado func(CambioEstado)
+
+// ActualizadorEstado permite cambiar el estado desde afuera (acuses de
+// entrega, rebotes y lecturas que informa el proveedor)
+type ActualizadorEstado interface {
+	ActualizarEstado(id string, nuevo EstadoNotificacion, detalle string) error
+}
+
+var (
+	_ ActualizadorEstado = (*EmailNotificador)(nil)
+	_ ActualizadorEstado = (*SMSNotificador)(nil)
+	_ ActualizadorEstado = (*SlackNotificador)(nil)
+	_ ActualizadorEstado = (*PushNotificador)(nil)
+)
+
+// observadoresEstado se incluye en cada notificador para aplicar las
+// transiciones y avisar a los suscriptores
+type observadoresEstado struct {
+	mu          sync.Mutex
+	manejadores []ManejadorCambioEstado
+}
+
+// AlCambiarEstado registra una función que se llama tras cada cambio de estado
+func (o *observadoresEstado) AlCambiarEstado(manejador ManejadorCambioEstado) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.manejadores = append(o.manejadores, manejador)
+}
+
+// cambiarEstado valida y aplica la transición y luego avisa
+func (o *observadoresEstado) cambiarEstado(registro *RegistroNotificacion, nuevo EstadoNotificacion, detalle string) error {
+	cambio, cambiado, err := aplicarEstado(registro, nuevo, detalle)
+	if cambiado {
+		o.avisar(cambio)
+	}
+	return err
+}
+
+// aplicarEstado valida y aplica la transición sin avisar, para quien
+// necesita hacerlo con un candado tomado y avisar después de soltarlo
+func aplicarEstado(registro *RegistroNotificacion, nuevo EstadoNotificacion, detalle string) (CambioEstado, bool, error) {
+	anterior := registro.Estado
+	if anterior == nuevo {
+		return CambioEstado{}, false, nil // los proveedores repiten acuses; no es un error
+	}
+	if !anterior.PuedePasarA(nuevo) {
+		return CambioEstado{}, false, fmt.Errorf("%w: %s → %s", ErrTransicionInvalida, anterior, nuevo)
+	}
+	registro.Estado = nuevo
+	return CambioEstado{
+		ID:       registro.ID,
+		Tipo:     registro.Tipo,
+		Anterior: anterior,
+		Nuevo:    nuevo,
+		Detalle:  detalle,
+		Fecha:    time.Now(),
+	}, true, nil
+}
+
+func (o *observadoresEstado) avisar(cambio CambioEstado) {
+	o.mu.Lock()
+	manejadores := append([]ManejadorCambioEstado(nil), o.manejadores...)
+	o.mu.Unlock()
+	for _, manejador := range manejadores {
+		manejador(cambio)
+	}
+}
+
+// ==========================================
+// RECEPTOR HTTP DE ACUSES DEL PROVEEDOR
+// ==========================================
+
+// Acuse es el aviso que envía el proveedor
+type Acuse struct {
+	ID      string             `json:"id"`
+	Estado  EstadoNotificacion `json:"estado"`
+	Detalle string             `json:"detalle,omitempty"`
+}
+
+// ReceptorAcuses es un http.Handler que recibe por POST un acuse en JSON,
+// o una lista de ellos, y actualiza el estado de cada notificación.
+// Cada petición debe traer el token en el encabezado X-Acuse-Token.
+type ReceptorAcuses struct {
+	destino ActualizadorEstado
+	token   string
+}
+
+// NuevoReceptorAcuses crea el receptor para un notificador o un
+// Despachador. El token es obligatorio: sin él cualquiera podría marcar
+// notificaciones como entregadas o rebotadas.
+func NuevoReceptorAcuses(destino ActualizadorEstado, token string) (*ReceptorAcuses, error) {
+	if destino == nil {
+		return nil, errors.New("el receptor de acuses necesita un destino")
+	}
+	if strings.TrimSpace(token) == "" {
+		return nil, errors.New("el receptor de acuses necesita un token")
+	}
+	return &ReceptorAcuses{destino: destino, token: token}, nil
+}
+
+// ServeHTTP responde 204 si aplicó todos los acuses, 400 si el cuerpo no
+// es válido, 404 si algún ID no existe y 409 si alguna transición no
+// está permitida. Los acuses válidos se aplican aunque otros fallen.
+func (r *ReceptorAcuses) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "método no permitido", http.StatusMethodNotAllowed)
+		return
+	}
+	if r.token == "" || subtle.ConstantTimeCompare([]byte(req.Header.Get("X-Acuse-Token")), []byte(r.token)) != 1 {
+		http.Error(w, "token inválido", http.StatusUnauthorized)
+		return
+	}
+
+	cuerpo, err := io.ReadAll(io.LimitReader(req.Body, 1<<20))
+	if err != nil {
+		http.Error(w, "no se pudo leer el cuerpo", http.StatusBadRequest)
+		return
+	}
+	acuses, err := decodificarAcuses(cuerpo)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	estado := http.StatusNoContent
+	var errores []string
+	for _, acuse := range acuses {
+		err := r.destino.ActualizarEstado(acuse.ID, acuse.Estado, acuse.Detalle)
+		switch {
+		case err == nil:
+			continue
+		case errors.Is(err, ErrNotificacionNoEncontrada):
+			estado = max(estado, http.StatusNotFound)
+		case errors.Is(err, ErrTransicionInvalida):
+			estado = max(estado, http.StatusConflict)
+		default:
+			estado = max(estado, http.StatusBadRequest)
+		}
+		errores = append(errores, fmt.Sprintf("%s: %v", acuse.ID, err))
+	}
+	if estado == http.StatusNoContent {
+		w.WriteHeader(estado)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(estado)
+	json.NewEncoder(w).Encode(map[string][]string{"errores": errores})
+}
+
+func decodificarAcuses(cuerpo []byte) ([]Acuse, error) {
+	var acuses []Acuse
+	if err := json.Unmarshal(cuerpo, &acuses); err != nil {
+		var acuse Acuse
+		if err := json.Unmarshal(cuerpo, &acuse); err != nil {
+			return nil, fmt.Errorf("JSON inválido: %w", err)
+		}
+		acuses = []Acuse{acuse}
+	}
+	for _, acuse := range acuses {
+		if acuse.ID == "" {
+			return nil, errors.New("acuse sin id")
+		}
+		switch acuse.Estado {
+		case Entregada, Rebotada, Leida, Fallida:
+		default:
+			return nil, fmt.Errorf("estado de acuse no válido: %q", acuse.Estado)
+		}
+	}
+	return acuses, nil
+}
@@ -0,0 +1,182 @@
+package interfaces
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNuevoReceptorAcusesExigeToken(t *testing.T) {
+	slack := NuevoSlackNotificador("http://localhost", "#general")
+	for _, token := range []string{"", "   "} {
+		if _, err := NuevoReceptorAcuses(slack, token); err == nil {
+			t.Errorf("token %q: se esperaba un error", token)
+		}
+	}
+	if _, err := NuevoReceptorAcuses(nil, "secreto"); err == nil {
+		t.Error("sin destino: se esperaba un error")
+	}
+}
+
+func TestReceptorAcusesAutenticacion(t *testing.T) {
+	_, url := levantarServidor(t)
+	slack := NuevoSlackNotificador(url, "#general")
+	id, err := slack.Enviar(context.Background(), "#general", Mensaje{Cuerpo: "hola"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	receptor, err := NuevoReceptorAcuses(slack, "secreto")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	casos := []struct {
+		nombre string
+		token  string
+		codigo int
+	}{
+		{"sin token", "", http.StatusUnauthorized},
+		{"token equivocado", "otro", http.StatusUnauthorized},
+		{"token correcto", "secreto", http.StatusNoContent},
+	}
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/acuses",
+				strings.NewReader(`{"id":"`+id+`","estado":"entregada"}`))
+			if caso.token != "" {
+				req.Header.Set("X-Acuse-Token", caso.token)
+			}
+			w := httptest.NewRecorder()
+			receptor.ServeHTTP(w, req)
+			if w.Code != caso.codigo {
+				t.Errorf("código %d, se esperaba %d: %s", w.Code, caso.codigo, w.Body)
+			}
+		})
+	}
+	if estado, _ := slack.ObtenerEstado(id); estado != string(Entregada) {
+		t.Errorf("estado %q, se esperaba %q", estado, Entregada)
+	}
+
+	// Un receptor sin constructor no debe quedar abierto
+	w := httptest.NewRecorder()
+	(&ReceptorAcuses{destino: slack}).ServeHTTP(w,
+		httptest.NewRequest(http.MethodPost, "/acuses", strings.NewReader(`{"id":"`+id+`","estado":"leida"}`)))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("receptor sin token respondió %d", w.Code)
+	}
+}
+
+func TestEstadoTransiciones(t *testing.T) {
+	casos := []struct {
+		de, a  EstadoNotificacion
+		valida bool
+	}{
+		{Pendiente, Enviado, true},
+		{Pendiente, Fallida, true},
+		{Pendiente, Entregada, false},
+		{Pendiente, Leida, false},
+		{Enviado, Entregada, true},
+		{Enviado, Fallida, true},
+		{Enviado, Rebotada, true},
+		{Enviado, Leida, true},
+		{Enviado, Pendiente, false},
+		{Entregada, Leida, true},
+		{Entregada, Rebotada, false},
+		{Entregada, Enviado, false},
+		{Leida, Entregada, false},
+		{Fallida, Enviado, false},
+		{Rebotada, Entregada, false},
+	}
+	for _, caso := range casos {
+		if got := caso.de.PuedePasarA(caso.a); got != caso.valida {
+			t.Errorf("%s → %s: %v, se esperaba %v", caso.de, caso.a, got, caso.valida)
+		}
+	}
+
+	finales := map[EstadoNotificacion]bool{
+		Pendiente: false, Enviado: false, Entregada: false,
+		Leida: true, Fallida: true, Rebotada: true,
+	}
+	for estado, final := range finales {
+		if estado.EsFinal() != final {
+			t.Errorf("%s.EsFinal() = %v, se esperaba %v", estado, !final, final)
+		}
+	}
+}
+
+func TestReceptorAcusesRespuestas(t *testing.T) {
+	_, url := levantarServidor(t)
+	slack := NuevoSlackNotificador(url, "#general")
+	ids := make([]string, 3)
+	for i := range ids {
+		id, err := slack.Enviar(context.Background(), "#general", Mensaje{Cuerpo: "hola"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[i] = id
+	}
+	var cambios []CambioEstado
+	slack.AlCambiarEstado(func(c CambioEstado) { cambios = append(cambios, c) })
+	receptor, err := NuevoReceptorAcuses(slack, "secreto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, b, c := ids[0], ids[1], ids[2]
+
+	casos := []struct {
+		nombre  string
+		cuerpo  string
+		codigo  int
+		cambios []CambioEstado // avisos que debe producir, sin la fecha
+	}{
+		{"entrega", `{"id":"` + a + `","estado":"entregada","detalle":"proveedor"}`, http.StatusNoContent,
+			[]CambioEstado{{ID: a, Tipo: Slack, Anterior: Enviado, Nuevo: Entregada, Detalle: "proveedor"}}},
+		{"acuse repetido", `{"id":"` + a + `","estado":"entregada"}`, http.StatusNoContent, nil},
+		{"ID inexistente", `{"id":"slack_0_0","estado":"entregada"}`, http.StatusNotFound, nil},
+		{"transición no permitida", `{"id":"` + a + `","estado":"rebotada"}`, http.StatusConflict, nil},
+		{"JSON inválido", `{"id":`, http.StatusBadRequest, nil},
+		{"acuse sin id", `{"estado":"entregada"}`, http.StatusBadRequest, nil},
+		{"estado que no es un acuse", `{"id":"` + a + `","estado":"pendiente"}`, http.StatusBadRequest, nil},
+		{"lote", `[{"id":"` + a + `","estado":"leida"},{"id":"` + b + `","estado":"rebotada","detalle":"buzón lleno"}]`,
+			http.StatusNoContent, []CambioEstado{
+				{ID: a, Tipo: Slack, Anterior: Entregada, Nuevo: Leida},
+				{ID: b, Tipo: Slack, Anterior: Enviado, Nuevo: Rebotada, Detalle: "buzón lleno"},
+			}},
+		// Los acuses válidos del lote se aplican; gana el código más alto
+		{"lote con errores", `[{"id":"slack_0_0","estado":"entregada"},{"id":"` + b + `","estado":"entregada"},` +
+			`{"id":"` + c + `","estado":"entregada"}]`, http.StatusConflict,
+			[]CambioEstado{{ID: c, Tipo: Slack, Anterior: Enviado, Nuevo: Entregada}}},
+	}
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			cambios = nil
+			req := httptest.NewRequest(http.MethodPost, "/acuses", strings.NewReader(caso.cuerpo))
+			req.Header.Set("X-Acuse-Token", "secreto")
+			w := httptest.NewRecorder()
+			receptor.ServeHTTP(w, req)
+			if w.Code != caso.codigo {
+				t.Errorf("código %d, se esperaba %d: %s", w.Code, caso.codigo, w.Body)
+			}
+			if len(cambios) != len(caso.cambios) {
+				t.Fatalf("avisos %+v, se esperaban %+v", cambios, caso.cambios)
+			}
+			for i, cambio := range cambios {
+				if cambio.Fecha.IsZero() {
+					t.Errorf("aviso %d sin fecha", i)
+				}
+				cambio.Fecha = caso.cambios[i].Fecha
+				if cambio != caso.cambios[i] {
+					t.Errorf("aviso %d: %+v, se esperaba %+v", i, cambio, caso.cambios[i])
+				}
+			}
+		})
+	}
+
+	for id, esperado := range map[string]EstadoNotificacion{a: Leida, b: Rebotada, c: Entregada} {
+		if estado, _ := slack.ObtenerEstado(id); estado != string(esperado) {
+			t.Errorf("%s: estado %q, se esperaba %q", id, estado, esperado)
+		}
+	}
+}
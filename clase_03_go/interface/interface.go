@@ -1,10 +1,23 @@
 package interfaces
 
 import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/mail"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
+
+	"FyS_proyect/telefono"
 )
 
 // ==========================================
@@ -22,7 +35,7 @@ type ValidadorMensaje interface {
 	ValidarDestinantario(destinatario string) error
 }
 
-//Rastreador permite hacer seguimiento de notificaciones
+// Rastreador permite hacer seguimiento de notificaciones
 type Rastreador interface {
 	ObtenerEstado(id string) (string, error)
 	ObtenerEstadisticas() map[string]int
@@ -73,6 +86,8 @@ const (
 	Enviado   EstadoNotificacion = "enviado"
 	Fallida   EstadoNotificacion = "fallida"
 	Entregada EstadoNotificacion = "entregada"
+	Rebotada  EstadoNotificacion = "rebotada" // el proveedor no pudo entregarla
+	Leida     EstadoNotificacion = "leida"
 )
 
 type RegistroNotificacion struct {
@@ -80,10 +95,14 @@ type RegistroNotificacion struct {
 	Tipo         TipoNotificacion
 	Destinatario string
 	Mensaje      string
+	CuerpoHTML   string // solo email
 	Estado       EstadoNotificacion
 	Timestamp    time.Time
 	Intentos     int
 	Error        error
+	Historial    []IntentoEnvio // un elemento por intento
+	Prioridad    Prioridad
+	Metadatos    map[string]string
 }
 
 type ConfiguracionNotificacion struct {
@@ -98,67 +117,90 @@ type ConfiguracionNotificacion struct {
 
 // EmailNotificador - Implementa múltiples interfaces
 type EmailNotificador struct {
-	servidor      string
-	puerto        int
-	usuario       string
-	password      string
-	configuracion ConfiguracionNotificacion
-	registros     map[string]*RegistroNotificacion
+	servidor   string
+	puerto     int
+	usuario    string
+	password   string
+	reintentos *MotorReintentos
+	rastreo
+
+	remitente        mail.Address
+	modoTLS          ModoTLS
+	autenticar       MecanismoAuth
+	tlsConfig        *tls.Config
+	reloj            func() time.Time // para Date; se reemplaza en pruebas
+	asuntoPorDefecto string
 }
 
-// Constructor para EmailNotificador
+// Constructor para EmailNotificador. Por defecto el From es el propio
+// usuario SMTP (cámbielo con ConfigurarRemitente) y el asunto sale de la
+// primera línea del mensaje: Enviar con Mensaje.Asunto la ocupa; un texto
+// de una sola línea va con el asunto "Notificación".
 func NuevoEmailNotificador(servidor string, puerto int, usuario string, password string, configuracion ConfiguracionNotificacion) *EmailNotificador {
 	return &EmailNotificador{
-		servidor: servidor,
-		puerto:   puerto,
-		usuario:  usuario,
-		password: password,
-		configuracion: ConfiguracionNotificacion{
-			MaxIntentos:     3,
-			TimeoutSegundos: 30,
-			ReintentoAuto:   true,
-		},
-		registros: make(map[string]*RegistroNotificacion),
+		servidor:         servidor,
+		puerto:           puerto,
+		usuario:          usuario,
+		password:         password,
+		rastreo:          nuevoRastreo(Email),
+		reintentos:       NuevoMotorReintentos(configuracion),
+		remitente:        mail.Address{Address: usuario},
+		modoTLS:          modoTLSPorPuerto(puerto),
+		autenticar:       AuthPlain,
+		reloj:            time.Now,
+		asuntoPorDefecto: "Notificación",
 	}
 }
 
 // Implementa Notificador
 func (e *EmailNotificador) EnviarNotificacion(destinatario, mensaje string) error {
+	_, err := e.Enviar(context.Background(), destinatario, MensajeDeTexto(mensaje))
+	return err
+}
+
+// Implementa NotificadorV2; retorna el ID aunque el envío falle, para
+// poder consultar el registro
+func (e *EmailNotificador) Enviar(ctx context.Context, destinatario string, m Mensaje) (string, error) {
+	mensaje := m.Texto()
 	// Validar antes de enviar
 	if err := e.ValidarDestinantario(destinatario); err != nil {
-		return err
+		return "", err
 	}
 	if err := e.ValidarMensaje(mensaje); err != nil {
-		return err
+		return "", err
 	}
 
 	// Crear registro
-	id := fmt.Sprintf("email_%d", time.Now().UnixNano())
+	id := e.nuevoID()
 	registro := &RegistroNotificacion{
 		ID:           id,
 		Tipo:         Email,
 		Destinatario: destinatario,
 		Mensaje:      mensaje,
+		CuerpoHTML:   m.CuerpoHTML,
 		Estado:       Pendiente,
+		Prioridad:    m.Prioridad,
+		Metadatos:    m.Metadatos,
 		Timestamp:    time.Now(),
-		Intentos:     1,
 	}
-	e.registros[id] = registro
-	// Simular envio de email
+	if err := e.registrar(registro); err != nil {
+		return "", err
+	}
 	e.LogInfo(fmt.Sprintf("Enviando email a %s", destinatario))
-	time.Sleep(100 * time.Millisecond) // Simular latencia
 
-	//Simular exito/fallo (90% de exito)
-	if time.Now().UnixNano()%10 == 0 {
-		registro.Estado = Fallida
-		registro.Error = errors.New("Servidor SMTP no disponible")
+	err := e.reintentos.Ejecutar(ctx, registro, e, func(ctx context.Context) error {
+		return e.entregarSMTP(ctx, registro)
+	})
+	if err != nil {
+		registro.Error = err
+		e.finalizar(registro, Fallida, err.Error())
 		e.LogError(registro.Error)
-		return errors.New("fallo al enviar email")
+		return id, fmt.Errorf("fallo al enviar email: %w", err)
 	}
 
-	registro.Estado = Enviado
+	e.finalizar(registro, Enviado, "")
 	e.LogInfo(fmt.Sprintf("Email exitosamente enviado a %s", id))
-	return nil
+	return id, nil
 }
 
 // Implementa ValidadorMensaje
@@ -182,35 +224,6 @@ func (e *EmailNotificador) ValidarDestinantario(destinatario string) error {
 	return nil
 }
 
-// Implementa Rastreador
-func (e *EmailNotificador) ObtenerEstado(id string) (string, error) {
-	if registro, existe := e.registros[id]; existe {
-		return string(registro.Estado), nil
-	}
-	return "", errors.New("Notificacion no encontrada")
-}
-
-func (e *EmailNotificador) ObtenerEstadisticas() map[string]int {
-	stats := map[string]int{
-		"total":      0,
-		"enviados":   0,
-		"fallidos":   0,
-		"pendientes": 0,
-	}
-	for _, registro := range e.registros {
-		stats["total"]++
-		switch registro.Estado {
-		case Enviado:
-			stats["enviados"]++
-		case Fallida:
-			stats["fallidos"]++
-		case Pendiente:
-			stats["pendientes"]++
-		}
-	}
-	return stats
-}
-
 // Implementa Logger
 func (e *EmailNotificador) Log(nivel, mensaje string) {
 	timestamp := time.Now().Format("2006-01-02 15:04:05")
@@ -228,120 +241,611 @@ func (e *EmailNotificador) LogInfo(mensaje string) {
 
 // SMSNotificador - Otra implementacion
 type SMSNotificador struct {
-	apiKey    string
-	proveedor string
-	registros map[string]*RegistroNotificacion
+	apiKey       string
+	proveedor    string
+	reintentos   *MotorReintentos
+	tarifa       float64 // precio por segmento
+	maxSegmentos int
+	pais         string        // país de los números sin código internacional
+	referencia   atomic.Uint32 // referencia de los UDH; el proveedor solo mira 8 bits
+	rastreo
 }
 
 func NuevoSMSNotificador(apiKey, proveedor string) *SMSNotificador {
 	return &SMSNotificador{
-		apiKey:    apiKey,
-		proveedor: proveedor,
-		registros: make(map[string]*RegistroNotificacion),
+		apiKey:       apiKey,
+		proveedor:    proveedor,
+		rastreo:      nuevoRastreo(SMS),
+		reintentos:   NuevoMotorReintentos(ConfiguracionPorDefecto()),
+		maxSegmentos: maxSegmentosPorDefecto,
+		pais:         "PE",
+	}
+}
+
+// ConfigurarPais define cómo se interpretan los números sin +código;
+// vacío exige que todos vengan en formato internacional
+func (s *SMSNotificador) ConfigurarPais(pais string) error {
+	if pais != "" && !telefono.Soportado(pais) {
+		return fmt.Errorf("país no soportado: %s", pais)
 	}
+	s.pais = pais
+	return nil
+}
+
+// ConfigurarTarifa define el precio por segmento que usa EstimarCosto
+func (s *SMSNotificador) ConfigurarTarifa(precioPorSegmento float64) {
+	s.tarifa = precioPorSegmento
+}
+
+// ConfigurarMaxSegmentos limita cuántos segmentos puede ocupar un mensaje
+// (como máximo 255, lo que admite el UDH)
+func (s *SMSNotificador) ConfigurarMaxSegmentos(n int) {
+	s.maxSegmentos = min(max(n, 1), 255)
+}
+
+// EstimarCosto retorna cómo se enviaría el mensaje y cuánto costaría
+func (s *SMSNotificador) EstimarCosto(mensaje string) (PlanSMS, float64) {
+	plan := SegmentarSMS(mensaje, 0)
+	return plan, plan.Costo(s.tarifa)
+}
+
+// ConfigurarReintentos reemplaza la configuración de reintentos y timeout
+func (s *SMSNotificador) ConfigurarReintentos(configuracion ConfiguracionNotificacion) {
+	s.reintentos = NuevoMotorReintentos(configuracion)
 }
 
 // Implementa Notificador
 func (s *SMSNotificador) EnviarNotificacion(destinatario, mensaje string) error {
-	if err := s.ValidarDestinantario(destinatario); err != nil {
-		return err
+	_, err := s.Enviar(context.Background(), destinatario, MensajeDeTexto(mensaje))
+	return err
+}
+
+// Implementa NotificadorV2; retorna el ID aunque el envío falle, para
+// poder consultar el registro
+func (s *SMSNotificador) Enviar(ctx context.Context, destinatario string, m Mensaje) (string, error) {
+	mensaje := m.Texto()
+	numero, err := s.analizarNumero(destinatario)
+	if err != nil {
+		return "", err
 	}
+	destinatario = numero.E164() // el registro guarda siempre el mismo formato
 	if err := s.ValidarMensaje(mensaje); err != nil {
-		return err
+		return "", err
+	}
+	plan := SegmentarSMS(mensaje, uint8(s.referencia.Add(1)))
+
+	// El detalle del envío queda en los metadatos sin tocar el mapa del llamador
+	metadatos := maps.Clone(m.Metadatos)
+	if metadatos == nil {
+		metadatos = make(map[string]string)
 	}
+	metadatos["sms_codificacion"] = string(plan.Codificacion)
+	metadatos["sms_segmentos"] = strconv.Itoa(len(plan.Segmentos))
+	metadatos["sms_costo"] = strconv.FormatFloat(plan.Costo(s.tarifa), 'f', -1, 64)
 
-	id := fmt.Sprintf("sms_%d", time.Now().UnixNano())
+	id := s.nuevoID()
 	registro := &RegistroNotificacion{
 		ID:           id,
 		Tipo:         SMS,
 		Destinatario: destinatario,
 		Mensaje:      mensaje,
 		Estado:       Pendiente,
+		Prioridad:    m.Prioridad,
+		Metadatos:    metadatos,
 		Timestamp:    time.Now(),
-		Intentos:     1,
 	}
-	s.registros[id] = registro
-	s.LogInfo(fmt.Sprintf("Enviando SMS a %s via %s", destinatario, s.proveedor))
-	time.Sleep(50 * time.Millisecond)
-
-	// SMS mas confiable (95% de exito)
-	if time.Now().UnixNano()%20 == 0 {
-		registro.Estado = Fallida
-		registro.Error = errors.New("Numero no valido")
+	if err := s.registrar(registro); err != nil {
+		return "", err
+	}
+	s.LogInfo(fmt.Sprintf("Enviando SMS a %s via %s (%d segmentos %s)",
+		destinatario, s.proveedor, len(plan.Segmentos), plan.Codificacion))
+
+	err = s.reintentos.Ejecutar(ctx, registro, s, func(ctx context.Context) error {
+		// Simular la entrega de cada segmento al proveedor
+		for range plan.Segmentos {
+			select {
+			case <-time.After(50 * time.Millisecond):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			// SMS mas confiable (95% de exito)
+			if time.Now().UnixNano()%20 == 0 {
+				return errors.New("proveedor SMS no disponible")
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		registro.Error = err
+		s.finalizar(registro, Fallida, err.Error())
 		s.LogError(registro.Error)
-		return errors.New("fallo al enviar SMS")
+		return id, fmt.Errorf("fallo al enviar SMS: %w", err)
 	}
 
-	registro.Estado = Enviado
+	s.finalizar(registro, Enviado, "")
 	s.LogInfo(fmt.Sprintf("SMS exitosamente enviado a %s", id))
-	return nil
+	return id, nil
 }
 
 // Implementa ValidadorMensaje
+// Los mensajes largos se envían en varios segmentos; el límite es la
+// cantidad de segmentos, no de bytes
 func (s *SMSNotificador) ValidarMensaje(mensaje string) error {
 	if len(mensaje) == 0 {
 		return errors.New("mensaje no puede estar vacío")
 	}
-	if len(mensaje) > 160 {
-		return errors.New("mensaje demasiado largo")
+	if n := len(SegmentarSMS(mensaje, 0).Segmentos); n > s.maxSegmentos {
+		return fmt.Errorf("mensaje demasiado largo: %d segmentos (máximo %d)", n, s.maxSegmentos)
 	}
 	return nil
 }
 
+// ValidarDestinantario exige un número válido para su país que no sea un
+// fijo; si el plan de numeración no distingue celulares (México, EE. UU.)
+// o el país no tiene plan, se acepta
 func (s *SMSNotificador) ValidarDestinantario(destinatario string) error {
-	if len(destinatario) < 10 {
-		return errors.New("numero de telefono muy corto")
+	_, err := s.analizarNumero(destinatario)
+	return err
+}
+
+func (s *SMSNotificador) analizarNumero(destinatario string) (telefono.Numero, error) {
+	numero, err := telefono.Analizar(destinatario, s.pais)
+	if err != nil {
+		return telefono.Numero{}, err
 	}
-	if !strings.HasPrefix(destinatario, "+") && !strings.HasPrefix(destinatario, "0") {
-		return errors.New("numero debe empezar con + o 0")
+	if numero.Tipo == telefono.Fijo {
+		return telefono.Numero{}, fmt.Errorf("%s es un teléfono fijo y no recibe SMS", numero)
 	}
-	return nil
+	return numero, nil
+}
+
+// Implementa Logger
+func (s *SMSNotificador) Log(nivel, mensaje string) {
+	timestamp := time.Now().Format("2006-01-02 15:04:05")
+	fmt.Printf("[%s] SMS [%s]: %s\n", timestamp, nivel, mensaje)
+}
+func (s *SMSNotificador) LogError(err error) {
+	s.Log("ERROR", err.Error())
 }
 
-// Implementa Rastreador
-func (s *SMSNotificador) ObtenerEstado(id string) (string, error) {
-	if registro, existe := s.registros[id]; existe {
-		return string(registro.Estado), nil
+func (s *SMSNotificador) LogInfo(mensaje string) {
+	s.Log("INFO", mensaje)
+}
+
+// ==========================================
+// SlackNotificador - Implementación más simple
+
+// limiteBloqueSlack es el máximo de caracteres de texto en un bloque de
+// sección y maxBloquesSlack el de bloques por mensaje. reservaMencionSlack
+// es el lugar que se deja para la mención "<@U…> " al validar el texto.
+const (
+	limiteBloqueSlack   = 3000
+	maxBloquesSlack     = 50
+	reservaMencionSlack = 32
+)
+
+// SlackNotificador publica mensajes en un incoming webhook de Slack.
+// El destinatario es un canal ("#general") o una persona ("@U024BE7LH"),
+// a quien se menciona en el canal por defecto.
+type SlackNotificador struct {
+	webhookURL string
+	canal      string
+	cliente    *http.Client
+	reintentos *MotorReintentos
+	rastreo
+}
+
+// Estructuras del payload de Slack
+type mensajeSlack struct {
+	Channel string        `json:"channel,omitempty"`
+	Text    string        `json:"text"`
+	Blocks  []bloqueSlack `json:"blocks,omitempty"`
+}
+
+type bloqueSlack struct {
+	Type string      `json:"type"`
+	Text *textoSlack `json:"text,omitempty"`
+}
+
+type textoSlack struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+func NuevoSlackNotificador(webhookURL, canal string) *SlackNotificador {
+	return &SlackNotificador{
+		webhookURL: webhookURL,
+		canal:      canal,
+		cliente:    &http.Client{},
+		rastreo:    nuevoRastreo(Slack),
+		reintentos: NuevoMotorReintentos(ConfiguracionPorDefecto()),
+	}
+}
+
+// ConfigurarReintentos reemplaza la configuración de reintentos y timeout
+func (s *SlackNotificador) ConfigurarReintentos(configuracion ConfiguracionNotificacion) {
+	s.reintentos = NuevoMotorReintentos(configuracion)
+}
+
+// Implementa Notificador
+func (s *SlackNotificador) EnviarNotificacion(destinatario, mensaje string) error {
+	_, err := s.Enviar(context.Background(), destinatario, MensajeDeTexto(mensaje))
+	return err
+}
+
+// Implementa NotificadorV2; retorna el ID aunque el envío falle, para
+// poder consultar el registro
+func (s *SlackNotificador) Enviar(ctx context.Context, destinatario string, m Mensaje) (string, error) {
+	mensaje := m.Texto()
+	if err := s.ValidarDestinantario(destinatario); err != nil {
+		return "", err
+	}
+	if err := s.ValidarMensaje(mensaje); err != nil {
+		return "", err
+	}
+
+	id := s.nuevoID()
+	registro := &RegistroNotificacion{
+		ID:           id,
+		Tipo:         Slack,
+		Destinatario: destinatario,
+		Mensaje:      mensaje,
+		Estado:       Pendiente,
+		Prioridad:    m.Prioridad,
+		Metadatos:    m.Metadatos,
+		Timestamp:    time.Now(),
+	}
+	if err := s.registrar(registro); err != nil {
+		return "", err
+	}
+	s.LogInfo(fmt.Sprintf("Publicando en Slack para %s", destinatario))
+
+	cuerpo, err := json.Marshal(s.armarPayload(destinatario, mensaje))
+	if err != nil {
+		return id, s.fallar(registro, err)
+	}
+	err = s.reintentos.Ejecutar(ctx, registro, s, func(ctx context.Context) error {
+		return s.publicar(ctx, cuerpo)
+	})
+	if err != nil {
+		return id, s.fallar(registro, err)
+	}
+
+	s.finalizar(registro, Enviado, "")
+	s.LogInfo(fmt.Sprintf("Mensaje de Slack exitosamente enviado %s", id))
+	return id, nil
+}
+
+// publicar hace un POST al webhook. Los 4xx (salvo 429) son permanentes:
+// reintentar el mismo payload daría el mismo error.
+func (s *SlackNotificador) publicar(ctx context.Context, cuerpo []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.webhookURL, bytes.NewReader(cuerpo))
+	if err != nil {
+		return Permanente(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.cliente.Do(req)
+	if err != nil {
+		return fmt.Errorf("Slack no disponible: %w", err)
+	}
+	defer resp.Body.Close()
+	respuesta, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	// Slack responde con un texto corto como "invalid_payload" o "channel_not_found"
+	err = fmt.Errorf("Slack rechazó el mensaje (%d): %s", resp.StatusCode, strings.TrimSpace(string(respuesta)))
+	if resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		return Permanente(err)
 	}
-	return "", errors.New("SMS no encontrado")
+	return err
 }
 
-func (s *SMSNotificador) ObtenerEstadisticas() map[string]int {
-	stats := map[string]int{
-		"total":      0,
-		"enviados":   0,
-		"fallidos":   0,
-		"pendientes": 0,
+// armarPayload arma el mensaje con un bloque de sección en mrkdwn; el
+// campo text queda como respaldo para las notificaciones del celular
+func (s *SlackNotificador) armarPayload(destinatario, mensaje string) mensajeSlack {
+	texto := escaparSlack(mensaje)
+	payload := mensajeSlack{Channel: s.canal, Text: texto}
+	if strings.HasPrefix(destinatario, "#") {
+		payload.Channel = destinatario
+	} else {
+		mencion := fmt.Sprintf("<@%s>", strings.TrimPrefix(destinatario, "@"))
+		texto = mencion + " " + texto
+		payload.Text = texto
 	}
-	for _, registro := range s.registros {
-		stats["total"]++
-		switch registro.Estado {
-		case Enviado:
-			stats["enviados"]++
-		case Fallida:
-			stats["fallidos"]++
-		case Pendiente:
-			stats["pendientes"]++
+	payload.Blocks = bloquesSlack(texto)
+	return payload
+}
+
+// escaparSlack protege &, < y >, que Slack interpreta como marcas de control
+func escaparSlack(texto string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(texto)
+}
+
+// bloquesSlack arma un bloque de sección por párrafo; un párrafo que es
+// solo "---" se convierte en un separador
+func bloquesSlack(texto string) []bloqueSlack {
+	var bloques []bloqueSlack
+	for _, parrafo := range strings.Split(strings.ReplaceAll(texto, "\r\n", "\n"), "\n\n") {
+		parrafo = strings.TrimSpace(parrafo)
+		switch parrafo {
+		case "":
+			continue
+		case "---":
+			bloques = append(bloques, bloqueSlack{Type: "divider"})
+		default:
+			bloques = append(bloques, bloqueSlack{
+				Type: "section",
+				Text: &textoSlack{Type: "mrkdwn", Text: parrafo},
+			})
 		}
 	}
-	return stats
+	return bloques
+}
+
+func (s *SlackNotificador) fallar(registro *RegistroNotificacion, err error) error {
+	registro.Error = err
+	s.finalizar(registro, Fallida, err.Error())
+	s.LogError(err)
+	return fmt.Errorf("fallo al enviar mensaje de Slack: %w", err)
+}
+
+// Implementa ValidadorMensaje. Se mide el texto como viaja en el payload:
+// escapado y con lugar para la mención.
+func (s *SlackNotificador) ValidarMensaje(mensaje string) error {
+	if len(strings.TrimSpace(mensaje)) == 0 {
+		return errors.New("mensaje no puede estar vacío")
+	}
+	texto := escaparSlack(mensaje)
+	if utf8.RuneCountInString(texto) > limiteBloqueSlack-reservaMencionSlack {
+		return errors.New("mensaje demasiado largo")
+	}
+	if len(bloquesSlack(texto)) > maxBloquesSlack {
+		return errors.New("mensaje con demasiados párrafos")
+	}
+	return nil
+}
+
+func (s *SlackNotificador) ValidarDestinantario(destinatario string) error {
+	if len(destinatario) < 2 {
+		return errors.New("destinatario de Slack muy corto")
+	}
+	if !strings.HasPrefix(destinatario, "#") && !strings.HasPrefix(destinatario, "@") {
+		return errors.New("destinatario debe empezar con # (canal) o @ (persona)")
+	}
+	if strings.ContainsAny(destinatario, " <>") {
+		return errors.New("destinatario de Slack no puede contener espacios ni < >")
+	}
+	// La mención "<@ID> " debe caber en la reserva que deja ValidarMensaje
+	if strings.HasPrefix(destinatario, "@") && len(destinatario)+3 > reservaMencionSlack {
+		return errors.New("ID de usuario de Slack demasiado largo")
+	}
+	return nil
 }
 
 // Implementa Logger
-func (s *SMSNotificador) Log(nivel, mensaje string) {
+func (s *SlackNotificador) Log(nivel, mensaje string) {
 	timestamp := time.Now().Format("2006-01-02 15:04:05")
-	fmt.Printf("[%s] SMS [%s]: %s\n", timestamp, nivel, mensaje)
+	fmt.Printf("[%s] SLACK [%s]: %s\n", timestamp, nivel, mensaje)
 }
-func (s *SMSNotificador) LogError(err error) {
+
+func (s *SlackNotificador) LogError(err error) {
 	s.Log("ERROR", err.Error())
 }
 
-func (s *SMSNotificador) LogInfo(mensaje string) {
+func (s *SlackNotificador) LogInfo(mensaje string) {
 	s.Log("INFO", mensaje)
 }
 
 // ==========================================
-// SlackNotificador - Implementación más simple
+// PushNotificador - Notificaciones a dispositivos
+
+// limitePayloadPush es el tamaño máximo del payload que aceptan los
+// servicios de push (Web Push y la mayoría de los proveedores móviles)
+const limitePayloadPush = 4096
+
+// idPushMasLargo tiene el largo máximo de un ID de push; con él
+// ValidarMensaje mide el peor caso antes de conocer el ID real
+const idPushMasLargo = "push_9223372036854775807_18446744073709551615"
+
+// PushNotificador envía notificaciones push a un servicio estilo Web Push.
+// El destinatario es el token del dispositivo; cada token recibe un POST
+// en <endpoint>/<token> con los encabezados TTL y Urgency.
+type PushNotificador struct {
+	endpoint   string
+	apiKey     string
+	titulo     string // título cuando el mensaje es de una sola línea
+	ttl        int    // segundos que el servicio guarda la notificación si el dispositivo está apagado
+	cliente    *http.Client
+	reintentos *MotorReintentos
+	rastreo
+}
+
+// payloadPush es el cuerpo JSON que recibe el dispositivo
+type payloadPush struct {
+	Titulo string `json:"titulo"`
+	Cuerpo string `json:"cuerpo"`
+	ID     string `json:"id"`
+}
+
+func NuevoPushNotificador(endpoint, apiKey, titulo string) *PushNotificador {
+	return &PushNotificador{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		apiKey:     apiKey,
+		titulo:     titulo,
+		ttl:        24 * 60 * 60,
+		cliente:    &http.Client{},
+		rastreo:    nuevoRastreo(Push),
+		reintentos: NuevoMotorReintentos(ConfiguracionPorDefecto()),
+	}
+}
+
+// ConfigurarReintentos reemplaza la configuración de reintentos y timeout
+func (p *PushNotificador) ConfigurarReintentos(configuracion ConfiguracionNotificacion) {
+	p.reintentos = NuevoMotorReintentos(configuracion)
+}
+
+// Implementa Notificador
+func (p *PushNotificador) EnviarNotificacion(destinatario, mensaje string) error {
+	_, err := p.Enviar(context.Background(), destinatario, MensajeDeTexto(mensaje))
+	return err
+}
+
+// Implementa NotificadorV2; retorna el ID aunque el envío falle, para
+// poder consultar el registro
+func (p *PushNotificador) Enviar(ctx context.Context, destinatario string, m Mensaje) (string, error) {
+	mensaje := m.Texto()
+	if err := p.ValidarDestinantario(destinatario); err != nil {
+		return "", err
+	}
+	if err := p.ValidarMensaje(mensaje); err != nil {
+		return "", err
+	}
+
+	id := p.nuevoID()
+	registro := &RegistroNotificacion{
+		ID:           id,
+		Tipo:         Push,
+		Destinatario: destinatario,
+		Mensaje:      mensaje,
+		Estado:       Pendiente,
+		Prioridad:    m.Prioridad,
+		Metadatos:    m.Metadatos,
+		Timestamp:    time.Now(),
+	}
+	if err := p.registrar(registro); err != nil {
+		return "", err
+	}
+	p.LogInfo(fmt.Sprintf("Enviando push al dispositivo %s", recortarToken(destinatario)))
+
+	cuerpo, err := json.Marshal(p.armarPayload(id, mensaje))
+	if err != nil {
+		return id, p.fallar(registro, err)
+	}
+	err = p.reintentos.Ejecutar(ctx, registro, p, func(ctx context.Context) error {
+		return p.entregar(ctx, destinatario, cuerpo, m.Prioridad)
+	})
+	if err != nil {
+		return id, p.fallar(registro, err)
+	}
+
+	p.finalizar(registro, Enviado, "")
+	p.LogInfo(fmt.Sprintf("Push exitosamente enviado %s", id))
+	return id, nil
+}
+
+// entregar hace el POST para un token; solo 429 y 5xx se reintentan
+func (p *PushNotificador) entregar(ctx context.Context, token string, cuerpo []byte, prioridad Prioridad) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint+"/"+token, bytes.NewReader(cuerpo))
+	if err != nil {
+		return Permanente(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "key="+p.apiKey)
+	req.Header.Set("TTL", strconv.Itoa(p.ttl))
+	req.Header.Set("Urgency", urgenciaPush(prioridad))
+
+	resp, err := p.cliente.Do(req)
+	if err != nil {
+		return fmt.Errorf("servicio push no disponible: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		// El dispositivo se dio de baja: el token no volverá a servir
+		return Permanente(errors.New("token de dispositivo vencido o inexistente"))
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return fmt.Errorf("el servicio push respondió %d", resp.StatusCode)
+	}
+	return Permanente(fmt.Errorf("el servicio push respondió %d", resp.StatusCode))
+}
+
+// armarPayload usa la primera línea como título si el mensaje tiene varias
+func (p *PushNotificador) armarPayload(id, mensaje string) payloadPush {
+	titulo, cuerpo, multilinea := strings.Cut(mensaje, "\n")
+	if !multilinea {
+		return payloadPush{Titulo: p.titulo, Cuerpo: mensaje, ID: id}
+	}
+	return payloadPush{Titulo: strings.TrimSpace(titulo), Cuerpo: strings.TrimSpace(cuerpo), ID: id}
+}
+
+func (p *PushNotificador) fallar(registro *RegistroNotificacion, err error) error {
+	registro.Error = err
+	p.finalizar(registro, Fallida, err.Error())
+	p.LogError(err)
+	return fmt.Errorf("fallo al enviar push: %w", err)
+}
+
+// Implementa ValidadorMensaje
+func (p *PushNotificador) ValidarMensaje(mensaje string) error {
+	if len(strings.TrimSpace(mensaje)) == 0 {
+		return errors.New("mensaje no puede estar vacío")
+	}
+	// Se mide el payload completo porque el límite es del servicio, no del texto
+	cuerpo, _ := json.Marshal(p.armarPayload(idPushMasLargo, mensaje))
+	if len(cuerpo) > limitePayloadPush {
+		return errors.New("mensaje demasiado largo")
+	}
+	return nil
+}
+
+func (p *PushNotificador) ValidarDestinantario(destinatario string) error {
+	if len(destinatario) < 16 {
+		return errors.New("token de dispositivo muy corto")
+	}
+	for _, r := range destinatario {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' ||
+			r == '-' || r == '_' || r == ':') {
+			return errors.New("token de dispositivo con caracteres no válidos")
+		}
+	}
+	return nil
+}
+
+// Implementa Logger
+func (p *PushNotificador) Log(nivel, mensaje string) {
+	timestamp := time.Now().Format("2006-01-02 15:04:05")
+	fmt.Printf("[%s] PUSH [%s]: %s\n", timestamp, nivel, mensaje)
+}
+
+func (p *PushNotificador) LogError(err error) {
+	p.Log("ERROR", err.Error())
+}
+
+func (p *PushNotificador) LogInfo(mensaje string) {
+	p.Log("INFO", mensaje)
+}
+
+// urgenciaPush traduce la prioridad a los valores de Urgency de Web Push (RFC 8030)
+func urgenciaPush(prioridad Prioridad) string {
+	switch prioridad {
+	case PrioridadBaja:
+		return "low"
+	case PrioridadAlta, PrioridadUrgente:
+		return "high"
+	}
+	return "normal"
+}
+
+// recortarToken evita escribir el token completo en los logs
+func recortarToken(token string) string {
+	if len(token) <= 8 {
+		return token
+	}
+	return token[:8] + "…"
+}
+
+// Verificación en compilación de que todos cumplen NotificadorAvanzado
+var (
+	_ NotificadorAvanzado = (*EmailNotificador)(nil)
+	_ NotificadorAvanzado = (*SMSNotificador)(nil)
+	_ NotificadorAvanzado = (*SlackNotificador)(nil)
+	_ NotificadorAvanzado = (*PushNotificador)(nil)
+)
 
 func main() {
 
@@ -0,0 +1,286 @@
+package interfaces
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// servidorPrueba responde con los códigos indicados, en orden (200 al
+// agotarse), y guarda cada petición recibida
+type servidorPrueba struct {
+	mu         sync.Mutex
+	codigos    []int
+	peticiones []peticionPrueba
+}
+
+type peticionPrueba struct {
+	Ruta      string
+	Cabeceras http.Header
+	Cuerpo    []byte
+}
+
+func (s *servidorPrueba) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cuerpo, _ := io.ReadAll(r.Body)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.peticiones = append(s.peticiones, peticionPrueba{Ruta: r.URL.Path, Cabeceras: r.Header.Clone(), Cuerpo: cuerpo})
+	codigo := http.StatusOK
+	if len(s.codigos) > 0 {
+		codigo, s.codigos = s.codigos[0], s.codigos[1:]
+	}
+	w.WriteHeader(codigo)
+}
+
+func (s *servidorPrueba) recibidas() []peticionPrueba {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]peticionPrueba(nil), s.peticiones...)
+}
+
+func levantarServidor(t *testing.T, codigos ...int) (*servidorPrueba, string) {
+	t.Helper()
+	receptor := &servidorPrueba{codigos: codigos}
+	servidor := httptest.NewServer(receptor)
+	t.Cleanup(servidor.Close)
+	return receptor, servidor.URL
+}
+
+// motorSinEsperas reintenta hasta tres veces sin dormir entre intentos
+func motorSinEsperas() *MotorReintentos {
+	motor := NuevoMotorReintentos(ConfiguracionNotificacion{MaxIntentos: 3, TimeoutSegundos: 5, ReintentoAuto: true})
+	motor.esperar = func(context.Context, time.Duration) error { return nil }
+	return motor
+}
+
+// ==========================================
+// Slack
+// ==========================================
+
+func TestSlackPayload(t *testing.T) {
+	casos := []struct {
+		nombre       string
+		destinatario string
+		mensaje      string
+		canal        string
+		texto        string
+		bloques      []bloqueSlack
+	}{
+		{
+			nombre:       "mención a una persona en el canal por defecto",
+			destinatario: "@U024BE7LH",
+			mensaje:      "Vence hoy <El Quijote> & otros\n\n---\n\nRenueva en *línea*",
+			canal:        "#general",
+			texto:        "<@U024BE7LH> Vence hoy &lt;El Quijote&gt; &amp; otros\n\n---\n\nRenueva en *línea*",
+			bloques: []bloqueSlack{
+				{Type: "section", Text: &textoSlack{Type: "mrkdwn", Text: "<@U024BE7LH> Vence hoy &lt;El Quijote&gt; &amp; otros"}},
+				{Type: "divider"},
+				{Type: "section", Text: &textoSlack{Type: "mrkdwn", Text: "Renueva en *línea*"}},
+			},
+		},
+		{
+			nombre:       "canal explícito sin mención",
+			destinatario: "#prestamos",
+			mensaje:      "a < b",
+			canal:        "#prestamos",
+			texto:        "a &lt; b",
+			bloques: []bloqueSlack{
+				{Type: "section", Text: &textoSlack{Type: "mrkdwn", Text: "a &lt; b"}},
+			},
+		},
+	}
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			receptor, url := levantarServidor(t)
+			slack := NuevoSlackNotificador(url+"/hooks/T000", "#general")
+			if _, err := slack.Enviar(context.Background(), caso.destinatario, Mensaje{Cuerpo: caso.mensaje}); err != nil {
+				t.Fatal(err)
+			}
+
+			recibidas := receptor.recibidas()
+			if len(recibidas) != 1 {
+				t.Fatalf("se esperaba un POST y llegaron %d", len(recibidas))
+			}
+			peticion := recibidas[0]
+			if peticion.Ruta != "/hooks/T000" {
+				t.Errorf("ruta %q", peticion.Ruta)
+			}
+			if got := peticion.Cabeceras.Get("Content-Type"); got != "application/json" {
+				t.Errorf("Content-Type = %q", got)
+			}
+			var payload mensajeSlack
+			if err := json.Unmarshal(peticion.Cuerpo, &payload); err != nil {
+				t.Fatal(err)
+			}
+			if payload.Channel != caso.canal {
+				t.Errorf("channel = %q, se esperaba %q", payload.Channel, caso.canal)
+			}
+			if payload.Text != caso.texto {
+				t.Errorf("text = %q, se esperaba %q", payload.Text, caso.texto)
+			}
+			if len(payload.Blocks) != len(caso.bloques) {
+				t.Fatalf("blocks = %d, se esperaban %d: %s", len(payload.Blocks), len(caso.bloques), peticion.Cuerpo)
+			}
+			for i, esperado := range caso.bloques {
+				bloque := payload.Blocks[i]
+				if bloque.Type != esperado.Type || (bloque.Text == nil) != (esperado.Text == nil) ||
+					bloque.Text != nil && *bloque.Text != *esperado.Text {
+					t.Errorf("bloque %d = %+v, se esperaba %+v", i, bloque, esperado)
+				}
+			}
+		})
+	}
+}
+
+func TestSlackValidarMensajeMideTextoEscapado(t *testing.T) {
+	slack := NuevoSlackNotificador("http://localhost", "#general")
+	largo := limiteBloqueSlack - reservaMencionSlack
+	if err := slack.ValidarMensaje(strings.Repeat("a", largo)); err != nil {
+		t.Errorf("un texto justo en el límite debe aceptarse: %v", err)
+	}
+	// Cada & ocupa cinco caracteres una vez escapado
+	if err := slack.ValidarMensaje(strings.Repeat("&", largo/5+1)); err == nil {
+		t.Error("se esperaba un error: el texto escapado supera el límite")
+	}
+	if err := slack.ValidarDestinantario("@" + strings.Repeat("U", reservaMencionSlack)); err == nil {
+		t.Error("se esperaba un error: la mención no cabe en la reserva")
+	}
+}
+
+func TestSlackCodigosDeRespuesta(t *testing.T) {
+	casos := []struct {
+		codigos    []int
+		intentos   int
+		exito      bool
+		permanente bool
+	}{
+		{[]int{400}, 1, false, true},
+		{[]int{404}, 1, false, true},
+		{[]int{429, 200}, 2, true, false},
+		{[]int{500, 502, 503}, 3, false, false},
+	}
+	for _, caso := range casos {
+		receptor, url := levantarServidor(t, caso.codigos...)
+		slack := NuevoSlackNotificador(url, "#general")
+		slack.reintentos = motorSinEsperas()
+		_, err := slack.Enviar(context.Background(), "#general", Mensaje{Cuerpo: "hola"})
+		if (err == nil) != caso.exito || EsPermanente(err) != caso.permanente {
+			t.Errorf("%v: error %v (permanente %v)", caso.codigos, err, EsPermanente(err))
+		}
+		if got := len(receptor.recibidas()); got != caso.intentos {
+			t.Errorf("%v: %d intentos, se esperaban %d", caso.codigos, got, caso.intentos)
+		}
+	}
+}
+
+// ==========================================
+// Push
+// ==========================================
+
+const tokenPrueba = "dispositivo_0123456789abcdef"
+
+func TestPushEncabezadosYRuta(t *testing.T) {
+	casos := []struct {
+		prioridad Prioridad
+		urgencia  string
+	}{
+		{PrioridadNormal, "normal"},
+		{PrioridadBaja, "low"},
+		{PrioridadAlta, "high"},
+		{PrioridadUrgente, "high"},
+	}
+	for _, caso := range casos {
+		t.Run(caso.prioridad.String(), func(t *testing.T) {
+			receptor, url := levantarServidor(t)
+			push := NuevoPushNotificador(url+"/v1/push/", "clave-secreta", "Biblioteca")
+			id, err := push.Enviar(context.Background(), tokenPrueba,
+				Mensaje{Asunto: "Préstamo por vencer", Cuerpo: "Devuelve El Quijote mañana", Prioridad: caso.prioridad})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			recibidas := receptor.recibidas()
+			if len(recibidas) != 1 {
+				t.Fatalf("se esperaba un POST y llegaron %d", len(recibidas))
+			}
+			peticion := recibidas[0]
+			if peticion.Ruta != "/v1/push/"+tokenPrueba {
+				t.Errorf("ruta %q, se esperaba <endpoint>/<token>", peticion.Ruta)
+			}
+			esperadas := map[string]string{
+				"Authorization": "key=clave-secreta",
+				"TTL":           "86400",
+				"Urgency":       caso.urgencia,
+				"Content-Type":  "application/json",
+			}
+			for cabecera, valor := range esperadas {
+				if got := peticion.Cabeceras.Get(cabecera); got != valor {
+					t.Errorf("%s = %q, se esperaba %q", cabecera, got, valor)
+				}
+			}
+			var payload payloadPush
+			if err := json.Unmarshal(peticion.Cuerpo, &payload); err != nil {
+				t.Fatal(err)
+			}
+			esperado := payloadPush{Titulo: "Préstamo por vencer", Cuerpo: "Devuelve El Quijote mañana", ID: id}
+			if payload != esperado {
+				t.Errorf("payload = %+v, se esperaba %+v", payload, esperado)
+			}
+		})
+	}
+}
+
+func TestPushCodigosDeRespuesta(t *testing.T) {
+	casos := []struct {
+		nombre     string
+		codigos    []int
+		intentos   int
+		exito      bool
+		permanente bool
+	}{
+		{"404 token inexistente", []int{404}, 1, false, true},
+		{"410 token dado de baja", []int{410}, 1, false, true},
+		{"400 payload rechazado", []int{400}, 1, false, true},
+		{"429 se reintenta", []int{429, 201}, 2, true, false},
+		{"5xx se reintenta", []int{500, 503, 200}, 3, true, false},
+		{"5xx agota los intentos", []int{502, 502, 502}, 3, false, false},
+	}
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			receptor, url := levantarServidor(t, caso.codigos...)
+			push := NuevoPushNotificador(url, "clave", "Biblioteca")
+			push.reintentos = motorSinEsperas()
+			id, err := push.Enviar(context.Background(), tokenPrueba, Mensaje{Cuerpo: "hola"})
+			if (err == nil) != caso.exito {
+				t.Fatalf("error %v, se esperaba éxito = %v", err, caso.exito)
+			}
+			if EsPermanente(err) != caso.permanente {
+				t.Errorf("EsPermanente = %v, se esperaba %v (%v)", EsPermanente(err), caso.permanente, err)
+			}
+			if got := len(receptor.recibidas()); got != caso.intentos {
+				t.Errorf("el servicio recibió %d intentos, se esperaban %d", got, caso.intentos)
+			}
+			registro, err := push.Almacen().Obtener(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if registro.Intentos != caso.intentos || len(registro.Historial) != caso.intentos {
+				t.Errorf("registro con %d intentos y %d en el historial, se esperaban %d",
+					registro.Intentos, len(registro.Historial), caso.intentos)
+			}
+			estado := Enviado
+			if !caso.exito {
+				estado = Fallida
+			}
+			if registro.Estado != estado {
+				t.Errorf("estado %s, se esperaba %s", registro.Estado, estado)
+			}
+		})
+	}
+}
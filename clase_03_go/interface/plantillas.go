@@ -0,0 +1,311 @@
+package interfaces
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"text/template"
+	"unicode/utf8"
+)
+
+// ==========================================
+// PLANTILLAS DE MENSAJES
+// ==========================================
+
+// VariantePlantilla es el texto de una plantilla para un canal y un
+// idioma. Canal o Idioma vacíos sirven para cualquiera que no tenga una
+// variante propia.
+type VariantePlantilla struct {
+	Canal      TipoNotificacion `json:"canal,omitempty"`
+	Idioma     string           `json:"idioma,omitempty"` // "es", "es-PE", "en"...
+	Asunto     string           `json:"asunto,omitempty"`
+	Cuerpo     string           `json:"cuerpo"`
+	CuerpoHTML string           `json:"cuerpo_html,omitempty"` // solo email; se escapa con html/template
+	// MaxSegmentos limita cuántos segmentos puede ocupar una variante de
+	// SMS con los datos de ejemplo; 0 exige que quepa en uno solo
+	MaxSegmentos int `json:"max_segmentos,omitempty"`
+}
+
+// Plantilla es un mensaje con nombre y sus variantes. Ejemplo son los
+// datos con que se prueba cada variante al cargarla.
+type Plantilla struct {
+	Nombre    string              `json:"nombre"`
+	Ejemplo   map[string]any      `json:"ejemplo,omitempty"`
+	Prioridad Prioridad           `json:"prioridad,omitempty"`
+	Variantes []VariantePlantilla `json:"variantes"`
+}
+
+// varianteCompilada guarda las plantillas ya analizadas
+type varianteCompilada struct {
+	VariantePlantilla
+	asunto *template.Template
+	cuerpo *template.Template
+	html   *htmltemplate.Template
+}
+
+// CatalogoPlantillas guarda plantillas por nombre y las valida contra el
+// ValidadorMensaje de cada canal al cargarlas
+type CatalogoPlantillas struct {
+	IdiomaPorDefecto string
+
+	mu          sync.RWMutex
+	validadores map[TipoNotificacion]ValidadorMensaje
+	plantillas  map[string]plantillaCompilada
+}
+
+type plantillaCompilada struct {
+	prioridad Prioridad
+	variantes []varianteCompilada
+}
+
+// NuevoCatalogoPlantillas crea un catálogo vacío; validadores suele ser
+// el mismo notificador de cada canal
+func NuevoCatalogoPlantillas(validadores map[TipoNotificacion]ValidadorMensaje) *CatalogoPlantillas {
+	c := &CatalogoPlantillas{
+		IdiomaPorDefecto: "es",
+		validadores:      make(map[TipoNotificacion]ValidadorMensaje),
+		plantillas:       make(map[string]plantillaCompilada),
+	}
+	for canal, validador := range validadores {
+		c.validadores[canal] = validador
+	}
+	return c
+}
+
+// funcionesPlantilla están disponibles en todas las plantillas
+var funcionesPlantilla = map[string]any{
+	"mayusculas": strings.ToUpper,
+	"minusculas": strings.ToLower,
+	// recortar deja a lo sumo n caracteres, terminando en "…" si cortó
+	"recortar": func(n int, texto string) string {
+		if utf8.RuneCountInString(texto) <= n {
+			return texto
+		}
+		runas := []rune(texto)
+		return string(runas[:max(n-1, 0)]) + "…"
+	},
+}
+
+// Cargar compila la plantilla y la valida: cada variante se renderiza
+// con los datos de ejemplo (faltar una variable es error) y el resultado
+// debe pasar ValidarMensaje del canal. Si hay error el catálogo no cambia.
+func (c *CatalogoPlantillas) Cargar(p Plantilla) error {
+	if p.Nombre == "" {
+		return errors.New("la plantilla no tiene nombre")
+	}
+	if len(p.Variantes) == 0 {
+		return fmt.Errorf("plantilla %s: no tiene variantes", p.Nombre)
+	}
+
+	compilada := plantillaCompilada{prioridad: p.Prioridad}
+	vistas := make(map[string]bool)
+	var errs []error
+	for _, variante := range p.Variantes {
+		clave := string(variante.Canal) + "/" + variante.Idioma
+		nombre := fmt.Sprintf("%s[%s]", p.Nombre, strings.Trim(clave, "/"))
+		if vistas[clave] {
+			errs = append(errs, fmt.Errorf("%s: variante repetida", nombre))
+			continue
+		}
+		vistas[clave] = true
+
+		v, err := compilarVariante(nombre, variante)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := c.validarVariante(nombre, v, p.Ejemplo); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		compilada.variantes = append(compilada.variantes, v)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.plantillas[p.Nombre] = compilada
+	return nil
+}
+
+// CargarJSON carga una lista de plantillas en JSON
+func (c *CatalogoPlantillas) CargarJSON(datos []byte) error {
+	var plantillas []Plantilla
+	if err := json.Unmarshal(datos, &plantillas); err != nil {
+		return fmt.Errorf("plantillas: JSON inválido: %w", err)
+	}
+	var errs []error
+	for _, p := range plantillas {
+		errs = append(errs, c.Cargar(p))
+	}
+	return errors.Join(errs...)
+}
+
+// CargarArchivo lee un archivo JSON con una lista de plantillas
+func (c *CatalogoPlantillas) CargarArchivo(ruta string) error {
+	datos, err := os.ReadFile(ruta)
+	if err != nil {
+		return err
+	}
+	return c.CargarJSON(datos)
+}
+
+// Nombres retorna los nombres de las plantillas cargadas
+func (c *CatalogoPlantillas) Nombres() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	nombres := make([]string, 0, len(c.plantillas))
+	for nombre := range c.plantillas {
+		nombres = append(nombres, nombre)
+	}
+	return nombres
+}
+
+// Renderizar arma el mensaje de la plantilla para un canal e idioma.
+// Si no hay variante para el idioma pedido ("es-PE") prueba con el
+// idioma base ("es"), luego con IdiomaPorDefecto y por último con la
+// variante sin idioma.
+func (c *CatalogoPlantillas) Renderizar(nombre string, canal TipoNotificacion, idioma string, datos any) (Mensaje, error) {
+	c.mu.RLock()
+	p, existe := c.plantillas[nombre]
+	c.mu.RUnlock()
+	if !existe {
+		return Mensaje{}, fmt.Errorf("plantilla %s no encontrada", nombre)
+	}
+	v, ok := p.elegir(canal, c.idiomasCandidatos(idioma))
+	if !ok {
+		return Mensaje{}, fmt.Errorf("plantilla %s: no hay variante para %s", nombre, canal)
+	}
+	mensaje, err := v.renderizar(datos)
+	if err != nil {
+		return Mensaje{}, fmt.Errorf("plantilla %s: %w", nombre, err)
+	}
+	mensaje.Prioridad = p.prioridad
+	mensaje.Metadatos = map[string]string{"plantilla": nombre, "idioma": v.Idioma}
+	return mensaje, nil
+}
+
+func (c *CatalogoPlantillas) idiomasCandidatos(idioma string) []string {
+	candidatos := []string{idioma}
+	if base, _, regional := strings.Cut(idioma, "-"); regional {
+		candidatos = append(candidatos, base)
+	}
+	return append(candidatos, c.IdiomaPorDefecto, "")
+}
+
+// elegir prefiere el canal exacto sobre la variante genérica y, dentro
+// de cada uno, el primer idioma candidato que exista
+func (p plantillaCompilada) elegir(canal TipoNotificacion, idiomas []string) (varianteCompilada, bool) {
+	for _, c := range []TipoNotificacion{canal, ""} {
+		for _, idioma := range idiomas {
+			for _, v := range p.variantes {
+				if v.Canal == c && strings.EqualFold(v.Idioma, idioma) {
+					return v, true
+				}
+			}
+		}
+	}
+	return varianteCompilada{}, false
+}
+
+func compilarVariante(nombre string, v VariantePlantilla) (varianteCompilada, error) {
+	if strings.TrimSpace(v.Cuerpo) == "" {
+		return varianteCompilada{}, fmt.Errorf("%s: cuerpo vacío", nombre)
+	}
+	if v.CuerpoHTML != "" && v.Canal != Email {
+		return varianteCompilada{}, fmt.Errorf("%s: cuerpo_html solo se usa en email", nombre)
+	}
+	if v.MaxSegmentos < 0 || v.MaxSegmentos > 0 && v.Canal != SMS {
+		return varianteCompilada{}, fmt.Errorf("%s: max_segmentos solo se usa en sms y no puede ser negativo", nombre)
+	}
+	compilada := varianteCompilada{VariantePlantilla: v}
+	var err error
+	if compilada.asunto, err = analizarTexto(nombre+".asunto", v.Asunto); err != nil {
+		return varianteCompilada{}, err
+	}
+	if compilada.cuerpo, err = analizarTexto(nombre+".cuerpo", v.Cuerpo); err != nil {
+		return varianteCompilada{}, err
+	}
+	if v.CuerpoHTML != "" {
+		compilada.html, err = htmltemplate.New(nombre + ".html").Option("missingkey=error").Funcs(funcionesPlantilla).Parse(v.CuerpoHTML)
+		if err != nil {
+			return varianteCompilada{}, err
+		}
+	}
+	return compilada, nil
+}
+
+func analizarTexto(nombre, texto string) (*template.Template, error) {
+	return template.New(nombre).Option("missingkey=error").Funcs(funcionesPlantilla).Parse(texto)
+}
+
+func (v varianteCompilada) renderizar(datos any) (Mensaje, error) {
+	var mensaje Mensaje
+	var err error
+	if mensaje.Asunto, err = ejecutar(v.asunto.Execute, datos); err != nil {
+		return Mensaje{}, err
+	}
+	if mensaje.Cuerpo, err = ejecutar(v.cuerpo.Execute, datos); err != nil {
+		return Mensaje{}, err
+	}
+	if v.html != nil {
+		if mensaje.CuerpoHTML, err = ejecutar(v.html.Execute, datos); err != nil {
+			return Mensaje{}, err
+		}
+	}
+	mensaje.Asunto = strings.TrimSpace(mensaje.Asunto)
+	mensaje.Cuerpo = strings.TrimSpace(mensaje.Cuerpo)
+	return mensaje, nil
+}
+
+func ejecutar(plantilla func(io.Writer, any) error, datos any) (string, error) {
+	var b bytes.Buffer
+	if err := plantilla(&b, datos); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// validarVariante renderiza con los datos de ejemplo y pasa el resultado
+// por el validador del canal, que debe estar registrado. Las variantes
+// genéricas se validan contra todos los canales registrados, porque
+// cualquiera puede terminar usándolas. En SMS además se cuentan los
+// segmentos, que son los que cobra el proveedor.
+func (c *CatalogoPlantillas) validarVariante(nombre string, v varianteCompilada, ejemplo map[string]any) error {
+	mensaje, err := v.renderizar(ejemplo)
+	if err != nil {
+		return fmt.Errorf("%s: %w", nombre, err)
+	}
+	canales := []TipoNotificacion{v.Canal}
+	if v.Canal == "" {
+		canales = canales[:0]
+		for canal := range c.validadores {
+			canales = append(canales, canal)
+		}
+	}
+	for _, canal := range canales {
+		validador, ok := c.validadores[canal]
+		if !ok {
+			return fmt.Errorf("%s: no hay validador registrado para %s", nombre, canal)
+		}
+		if err := validador.ValidarMensaje(mensaje.Texto()); err != nil {
+			return fmt.Errorf("%s: no pasa la validación de %s: %w", nombre, canal, err)
+		}
+		if canal == SMS {
+			plan := SegmentarSMS(mensaje.Texto(), 0)
+			if limite := max(v.MaxSegmentos, 1); len(plan.Segmentos) > limite {
+				return fmt.Errorf("%s: ocupa %d segmentos SMS (%d unidades %s) y el máximo es %d",
+					nombre, len(plan.Segmentos), plan.Unidades, plan.Codificacion, limite)
+			}
+		}
+	}
+	return nil
+}
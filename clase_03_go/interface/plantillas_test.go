@@ -0,0 +1,42 @@
+package interfaces
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCatalogoPlantillasValidacion(t *testing.T) {
+	ejemplo := map[string]any{"Titulo": "El Quijote"}
+	// 150 caracteres GSM-7 con el título de ejemplo: caben en un segmento
+	corto := strings.Repeat("a", 140) + "{{.Titulo}}"
+	largo := strings.Repeat("a", 200) + "{{.Titulo}}"
+	casos := []struct {
+		nombre   string
+		variante VariantePlantilla
+		error    string // vacío si debe cargarse
+	}{
+		{"SMS de un segmento", VariantePlantilla{Canal: SMS, Cuerpo: corto}, ""},
+		{"SMS de dos segmentos sin permiso", VariantePlantilla{Canal: SMS, Cuerpo: largo}, "ocupa 2 segmentos SMS"},
+		{"SMS de dos segmentos permitidos", VariantePlantilla{Canal: SMS, Cuerpo: largo, MaxSegmentos: 2}, ""},
+		// Con "á" el texto pasa a UCS-2, donde un segmento lleva 70 unidades
+		{"SMS en UCS-2", VariantePlantilla{Canal: SMS, Cuerpo: "á" + strings.Repeat("a", 80)}, "ocupa 2 segmentos SMS"},
+		{"genérica que no cabe en un SMS", VariantePlantilla{Cuerpo: largo}, "ocupa 2 segmentos SMS"},
+		{"max_segmentos fuera de SMS", VariantePlantilla{Canal: Slack, Cuerpo: "hola", MaxSegmentos: 2}, "max_segmentos"},
+		{"canal sin validador", VariantePlantilla{Canal: Push, Cuerpo: "hola"}, "no hay validador registrado para push"},
+	}
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			catalogo := NuevoCatalogoPlantillas(map[TipoNotificacion]ValidadorMensaje{
+				SMS:   NuevoSMSNotificador("clave", "proveedor"),
+				Slack: NuevoSlackNotificador("http://localhost", "#general"),
+			})
+			err := catalogo.Cargar(Plantilla{Nombre: "aviso", Ejemplo: ejemplo, Variantes: []VariantePlantilla{caso.variante}})
+			switch {
+			case caso.error == "" && err != nil:
+				t.Errorf("no debía fallar: %v", err)
+			case caso.error != "" && (err == nil || !strings.Contains(err.Error(), caso.error)):
+				t.Errorf("error %v, se esperaba uno con %q", err, caso.error)
+			}
+		})
+	}
+}
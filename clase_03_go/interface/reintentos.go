@@ -0,0 +1,169 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// ==========================================
+// REINTENTOS CON BACKOFF Y TIMEOUT
+// ==========================================
+
+// Valores usados cuando la configuración deja un campo en cero
+const (
+	maxIntentosPorDefecto = 3
+	timeoutPorDefecto     = 30
+	backoffBase           = 500 * time.Millisecond
+	backoffMaximo         = 30 * time.Second
+)
+
+// IntentoEnvio es el detalle de un intento guardado en el registro
+type IntentoEnvio struct {
+	Numero   int
+	Inicio   time.Time
+	Duracion time.Duration
+	Error    error // nil si el intento tuvo éxito
+}
+
+// errorPermanente marca fallas que no se arreglan reintentando
+type errorPermanente struct {
+	err error
+}
+
+func (e errorPermanente) Error() string { return e.err.Error() }
+func (e errorPermanente) Unwrap() error { return e.err }
+
+// Permanente envuelve un error para que el motor no lo reintente
+// (destinatario rechazado, credenciales inválidas, payload mal formado...)
+func Permanente(err error) error {
+	if err == nil {
+		return nil
+	}
+	return errorPermanente{err}
+}
+
+// EsPermanente indica si el error no debe reintentarse
+func EsPermanente(err error) bool {
+	var p errorPermanente
+	return errors.As(err, &p)
+}
+
+// ConfiguracionPorDefecto retorna la configuración usada cuando no se indica otra
+func ConfiguracionPorDefecto() ConfiguracionNotificacion {
+	return ConfiguracionNotificacion{
+		MaxIntentos:     maxIntentosPorDefecto,
+		TimeoutSegundos: timeoutPorDefecto,
+		ReintentoAuto:   true,
+	}
+}
+
+// completarConfiguracion llena los campos en cero con los valores por defecto
+func completarConfiguracion(c ConfiguracionNotificacion) ConfiguracionNotificacion {
+	if c == (ConfiguracionNotificacion{}) {
+		return ConfiguracionPorDefecto()
+	}
+	if c.MaxIntentos <= 0 {
+		c.MaxIntentos = maxIntentosPorDefecto
+	}
+	if c.TimeoutSegundos <= 0 {
+		c.TimeoutSegundos = timeoutPorDefecto
+	}
+	return c
+}
+
+// MotorReintentos ejecuta un envío respetando ConfiguracionNotificacion:
+// hasta MaxIntentos intentos (uno solo si ReintentoAuto es false), cada
+// uno limitado a TimeoutSegundos, con backoff exponencial y jitter.
+type MotorReintentos struct {
+	configuracion ConfiguracionNotificacion
+	BackoffBase   time.Duration
+	BackoffMaximo time.Duration
+
+	esperar func(context.Context, time.Duration) error // reemplazable en pruebas
+	azar    func() float64
+}
+
+// NuevoMotorReintentos crea un motor para la configuración dada
+func NuevoMotorReintentos(configuracion ConfiguracionNotificacion) *MotorReintentos {
+	return &MotorReintentos{
+		configuracion: completarConfiguracion(configuracion),
+		BackoffBase:   backoffBase,
+		BackoffMaximo: backoffMaximo,
+		esperar:       esperarContexto,
+		azar:          rand.Float64,
+	}
+}
+
+// Configuracion retorna la configuración efectiva
+func (m *MotorReintentos) Configuracion() ConfiguracionNotificacion {
+	return m.configuracion
+}
+
+// Ejecutar llama a enviar hasta que tenga éxito, falle con un error
+// permanente, se agoten los intentos o se cancele ctx. Cada intento queda
+// anotado en el registro.
+func (m *MotorReintentos) Ejecutar(ctx context.Context, registro *RegistroNotificacion, logger Logger,
+	enviar func(ctx context.Context) error) error {
+	maxIntentos := m.configuracion.MaxIntentos
+	if !m.configuracion.ReintentoAuto {
+		maxIntentos = 1
+	}
+	timeout := time.Duration(m.configuracion.TimeoutSegundos) * time.Second
+
+	var err error
+	for intento := 1; intento <= maxIntentos; intento++ {
+		if errCtx := ctx.Err(); errCtx != nil {
+			return errCtx
+		}
+		ctxIntento, cancelar := context.WithTimeout(ctx, timeout)
+		inicio := time.Now()
+		err = enviar(ctxIntento)
+		cancelar()
+
+		registro.Intentos = intento
+		registro.Historial = append(registro.Historial, IntentoEnvio{
+			Numero: intento, Inicio: inicio, Duracion: time.Since(inicio), Error: err,
+		})
+		if err == nil {
+			return nil
+		}
+		if EsPermanente(err) || ctx.Err() != nil || intento == maxIntentos {
+			break
+		}
+
+		espera := m.backoff(intento)
+		if logger != nil {
+			logger.Log("WARN", fmt.Sprintf("Intento %d/%d fallido (%v); reintento en %s",
+				intento, maxIntentos, err, espera.Round(time.Millisecond)))
+		}
+		if errEspera := m.esperar(ctx, espera); errEspera != nil {
+			return errEspera
+		}
+	}
+	return err
+}
+
+// backoff calcula la espera tras el intento n: base·2^(n-1), con tope,
+// y un jitter que la deja entre la mitad y el total para que varios
+// envíos fallidos a la vez no reintenten sincronizados
+func (m *MotorReintentos) backoff(n int) time.Duration {
+	espera := m.BackoffBase << (n - 1)
+	if espera <= 0 || espera > m.BackoffMaximo {
+		espera = m.BackoffMaximo
+	}
+	return espera/2 + time.Duration(m.azar()*float64(espera/2))
+}
+
+func esperarContexto(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,163 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// motorPrueba crea un motor que anota las esperas en lugar de dormir
+func motorPrueba(configuracion ConfiguracionNotificacion, azar float64) (*MotorReintentos, *[]time.Duration) {
+	motor := NuevoMotorReintentos(configuracion)
+	motor.BackoffBase = 100 * time.Millisecond
+	motor.BackoffMaximo = 300 * time.Millisecond
+	motor.azar = func() float64 { return azar }
+	esperas := &[]time.Duration{}
+	motor.esperar = func(_ context.Context, d time.Duration) error {
+		*esperas = append(*esperas, d)
+		return nil
+	}
+	return motor, esperas
+}
+
+func TestBackoffLimites(t *testing.T) {
+	casos := []struct {
+		intento int
+		azar    float64
+		espera  time.Duration
+	}{
+		{1, 0, 50 * time.Millisecond},
+		{1, 1, 100 * time.Millisecond},
+		{2, 0, 100 * time.Millisecond},
+		{2, 0.5, 150 * time.Millisecond},
+		{3, 0, 150 * time.Millisecond}, // 400ms supera el tope de 300ms
+		{3, 1, 300 * time.Millisecond},
+		{10, 1, 300 * time.Millisecond},
+		{70, 1, 300 * time.Millisecond}, // el desplazamiento desborda
+	}
+	for _, caso := range casos {
+		motor, _ := motorPrueba(ConfiguracionPorDefecto(), caso.azar)
+		if got := motor.backoff(caso.intento); got != caso.espera {
+			t.Errorf("backoff(%d) con azar %v = %v, se esperaba %v", caso.intento, caso.azar, got, caso.espera)
+		}
+	}
+}
+
+func TestMotorReintentosEjecutar(t *testing.T) {
+	errTemporal := errors.New("servicio no disponible")
+	errRechazo := Permanente(errors.New("destinatario rechazado"))
+
+	casos := []struct {
+		nombre        string
+		configuracion ConfiguracionNotificacion
+		errores       []error // resultado de cada intento; nil al agotarse
+		intentos      int
+		esperas       []time.Duration
+		final         error
+	}{
+		{"éxito al primer intento", ConfiguracionNotificacion{MaxIntentos: 3, ReintentoAuto: true},
+			nil, 1, nil, nil},
+		{"reintenta hasta tener éxito", ConfiguracionNotificacion{MaxIntentos: 4, ReintentoAuto: true},
+			[]error{errTemporal, errTemporal}, 3,
+			[]time.Duration{100 * time.Millisecond, 200 * time.Millisecond}, nil},
+		{"agota los intentos con el tope de backoff", ConfiguracionNotificacion{MaxIntentos: 4, ReintentoAuto: true},
+			[]error{errTemporal, errTemporal, errTemporal, errTemporal}, 4,
+			[]time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond}, errTemporal},
+		{"un error permanente corta los reintentos", ConfiguracionNotificacion{MaxIntentos: 5, ReintentoAuto: true},
+			[]error{errTemporal, errRechazo}, 2,
+			[]time.Duration{100 * time.Millisecond}, errRechazo},
+		{"sin ReintentoAuto hace un solo intento", ConfiguracionNotificacion{MaxIntentos: 5, ReintentoAuto: false},
+			[]error{errTemporal}, 1, nil, errTemporal},
+		{"configuración vacía usa la de por defecto", ConfiguracionNotificacion{},
+			[]error{errTemporal, errTemporal, errTemporal}, maxIntentosPorDefecto,
+			[]time.Duration{100 * time.Millisecond, 200 * time.Millisecond}, errTemporal},
+	}
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			motor, esperas := motorPrueba(caso.configuracion, 1)
+			registro := &RegistroNotificacion{}
+			llamadas := 0
+			err := motor.Ejecutar(context.Background(), registro, nil, func(context.Context) error {
+				llamadas++
+				if llamadas <= len(caso.errores) {
+					return caso.errores[llamadas-1]
+				}
+				return nil
+			})
+
+			if err != caso.final {
+				t.Errorf("error final %v, se esperaba %v", err, caso.final)
+			}
+			if llamadas != caso.intentos {
+				t.Errorf("%d llamadas, se esperaban %d", llamadas, caso.intentos)
+			}
+			if len(*esperas) != len(caso.esperas) {
+				t.Fatalf("esperas = %v, se esperaba %v", *esperas, caso.esperas)
+			}
+			for i := range caso.esperas {
+				if (*esperas)[i] != caso.esperas[i] {
+					t.Errorf("espera %d = %v, se esperaba %v", i+1, (*esperas)[i], caso.esperas[i])
+				}
+			}
+
+			if registro.Intentos != caso.intentos || len(registro.Historial) != caso.intentos {
+				t.Fatalf("Intentos = %d con %d en el historial, se esperaban %d",
+					registro.Intentos, len(registro.Historial), caso.intentos)
+			}
+			for i, intento := range registro.Historial {
+				var esperado error
+				if i < len(caso.errores) {
+					esperado = caso.errores[i]
+				}
+				if intento.Numero != i+1 || intento.Error != esperado || intento.Inicio.IsZero() {
+					t.Errorf("historial %d = %+v, se esperaba Numero %d y Error %v", i, intento, i+1, esperado)
+				}
+			}
+		})
+	}
+}
+
+func TestMotorReintentosTimeoutPorIntento(t *testing.T) {
+	motor, _ := motorPrueba(ConfiguracionNotificacion{MaxIntentos: 2, TimeoutSegundos: 1, ReintentoAuto: true}, 1)
+	var plazos []time.Duration
+	err := motor.Ejecutar(context.Background(), &RegistroNotificacion{}, nil, func(ctx context.Context) error {
+		limite, ok := ctx.Deadline()
+		if !ok {
+			t.Fatal("cada intento debe llevar un plazo")
+		}
+		plazos = append(plazos, time.Until(limite))
+		return context.DeadlineExceeded
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error final %v", err)
+	}
+	if len(plazos) != 2 {
+		t.Fatalf("%d intentos, se esperaban 2", len(plazos))
+	}
+	// El plazo es nuevo en cada intento, no el que quedó del anterior
+	for i, plazo := range plazos {
+		if plazo <= 900*time.Millisecond || plazo > time.Second {
+			t.Errorf("intento %d con plazo %v, se esperaba cerca de 1s", i+1, plazo)
+		}
+	}
+}
+
+func TestMotorReintentosCancelado(t *testing.T) {
+	motor, _ := motorPrueba(ConfiguracionNotificacion{MaxIntentos: 5, ReintentoAuto: true}, 1)
+	ctx, cancelar := context.WithCancel(context.Background())
+	motor.esperar = func(ctx context.Context, _ time.Duration) error {
+		cancelar()
+		return ctx.Err()
+	}
+	registro := &RegistroNotificacion{}
+	err := motor.Ejecutar(ctx, registro, nil, func(context.Context) error {
+		return errors.New("servicio no disponible")
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("error final %v, se esperaba context.Canceled", err)
+	}
+	if registro.Intentos != 1 {
+		t.Errorf("Intentos = %d; cancelar durante la espera no debe reintentar", registro.Intentos)
+	}
+}
@@ -0,0 +1,136 @@
+package interfaces
+
+import (
+	"strings"
+)
+
+// ==========================================
+// CODIFICACIÓN Y SEGMENTACIÓN DE SMS
+// ==========================================
+
+// CodificacionSMS es el alfabeto con que viaja el mensaje
+type CodificacionSMS string
+
+const (
+	// GSM7 usa 7 bits por carácter; solo admite el alfabeto GSM 03.38
+	GSM7 CodificacionSMS = "GSM-7"
+	// UCS2 usa 16 bits por carácter; admite cualquier texto
+	UCS2 CodificacionSMS = "UCS-2"
+)
+
+// Límites de un segmento: el encabezado UDH de los mensajes concatenados
+// ocupa 6 bytes, que se descuentan del espacio para texto
+const (
+	limiteGSM7             = 160
+	limiteGSM7Multiple     = 153
+	limiteUCS2             = 70
+	limiteUCS2Multiple     = 67
+	maxSegmentosPorDefecto = 10
+)
+
+// basicoGSM es la tabla básica de GSM 03.38 (un septeto por carácter)
+const basicoGSM = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+	"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+
+// extensionGSM son los caracteres que necesitan escape (dos septetos)
+const extensionGSM = "\f^{}\\[~]|€"
+
+// unidadesGSM retorna cuántos septetos ocupa r, o false si r no existe en GSM-7
+func unidadesGSM(r rune) (int, bool) {
+	switch {
+	case strings.ContainsRune(basicoGSM, r):
+		return 1, true
+	case strings.ContainsRune(extensionGSM, r):
+		return 2, true
+	}
+	return 0, false
+}
+
+// unidadesUCS2 retorna cuántas unidades UTF-16 ocupa r; los emojis
+// y otros caracteres fuera del plano básico ocupan dos
+func unidadesUCS2(r rune) int {
+	if r > 0xFFFF {
+		return 2
+	}
+	return 1
+}
+
+// DetectarCodificacion elige GSM-7 si todo el texto cabe en ese
+// alfabeto y UCS-2 en otro caso. Ojo: á, í, ó y ú no están en GSM-7.
+func DetectarCodificacion(texto string) CodificacionSMS {
+	for _, r := range texto {
+		if _, ok := unidadesGSM(r); !ok {
+			return UCS2
+		}
+	}
+	return GSM7
+}
+
+// SegmentoSMS es una de las partes en que viaja un mensaje
+type SegmentoSMS struct {
+	Numero int // desde 1
+	Texto  string
+	UDH    []byte // encabezado de concatenación; nil si el mensaje va en un solo segmento
+}
+
+// PlanSMS describe cómo se envía un texto
+type PlanSMS struct {
+	Codificacion CodificacionSMS
+	Unidades     int // septetos en GSM-7, unidades UTF-16 en UCS-2
+	Segmentos    []SegmentoSMS
+}
+
+// Costo estima el precio del envío; los proveedores cobran por segmento
+func (p PlanSMS) Costo(precioPorSegmento float64) float64 {
+	return float64(len(p.Segmentos)) * precioPorSegmento
+}
+
+// SegmentarSMS divide el texto en segmentos. Si hace falta más de uno,
+// cada segmento lleva un UDH con la referencia (igual en todas las
+// partes del mismo mensaje), el total y su número, para que el teléfono
+// los vuelva a unir. Nunca se corta un escape GSM ni un par sustituto UTF-16.
+func SegmentarSMS(texto string, referencia uint8) PlanSMS {
+	codificacion := DetectarCodificacion(texto)
+	unidades := func(r rune) int {
+		if codificacion == GSM7 {
+			n, _ := unidadesGSM(r)
+			return n
+		}
+		return unidadesUCS2(r)
+	}
+	limite, limiteMultiple := limiteGSM7, limiteGSM7Multiple
+	if codificacion == UCS2 {
+		limite, limiteMultiple = limiteUCS2, limiteUCS2Multiple
+	}
+
+	plan := PlanSMS{Codificacion: codificacion}
+	for _, r := range texto {
+		plan.Unidades += unidades(r)
+	}
+	if plan.Unidades <= limite {
+		plan.Segmentos = []SegmentoSMS{{Numero: 1, Texto: texto}}
+		return plan
+	}
+
+	var actual strings.Builder
+	ocupadas := 0
+	for _, r := range texto {
+		n := unidades(r)
+		if ocupadas+n > limiteMultiple {
+			plan.Segmentos = append(plan.Segmentos, SegmentoSMS{Texto: actual.String()})
+			actual.Reset()
+			ocupadas = 0
+		}
+		actual.WriteRune(r)
+		ocupadas += n
+	}
+	plan.Segmentos = append(plan.Segmentos, SegmentoSMS{Texto: actual.String()})
+
+	total := len(plan.Segmentos)
+	for i := range plan.Segmentos {
+		plan.Segmentos[i].Numero = i + 1
+		// IEI 0x00: concatenación con referencia de 8 bits
+		plan.Segmentos[i].UDH = []byte{0x05, 0x00, 0x03, referencia, byte(total), byte(i + 1)}
+	}
+	return plan
+}
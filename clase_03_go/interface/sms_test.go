@@ -0,0 +1,210 @@
+package interfaces
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestDetectarCodificacion(t *testing.T) {
+	casos := []struct {
+		texto        string
+		codificacion CodificacionSMS
+	}{
+		{"Hola, su libro vence mañana", GSM7},
+		{"Multa: 5 € {pagar} [hoy]", GSM7},
+		{"Ñandú", UCS2}, // ú no está en GSM-7
+		{"canción", UCS2},
+		{"listo 😀", UCS2},
+		{"", GSM7},
+	}
+	for _, caso := range casos {
+		if got := DetectarCodificacion(caso.texto); got != caso.codificacion {
+			t.Errorf("DetectarCodificacion(%q) = %s, se esperaba %s", caso.texto, got, caso.codificacion)
+		}
+	}
+}
+
+func TestSegmentarSMS(t *testing.T) {
+	emojis := "😀👍" // dos pares sustitutos: cuatro unidades UTF-16
+	casos := []struct {
+		nombre       string
+		texto        string
+		codificacion CodificacionSMS
+		unidades     int
+		partes       []string // texto de cada segmento
+	}{
+		{"160 caracteres GSM-7", strings.Repeat("a", 160), GSM7, 160,
+			[]string{strings.Repeat("a", 160)}},
+		{"161 caracteres GSM-7", strings.Repeat("a", 161), GSM7, 161,
+			[]string{strings.Repeat("a", 153), strings.Repeat("a", 8)}},
+		// Cada € ocupa dos septetos: 152 + 4 cabe en un solo segmento
+		{"152 caracteres y dos escapes", strings.Repeat("a", 152) + "€€", GSM7, 156,
+			[]string{strings.Repeat("a", 152) + "€€"}},
+		// El escape no cabe en el septeto 153: pasa entero al segmento siguiente
+		{"escape en el límite", strings.Repeat("a", 152) + "€€" + strings.Repeat("b", 10), GSM7, 166,
+			[]string{strings.Repeat("a", 152), "€€" + strings.Repeat("b", 10)}},
+		{"llaves en el límite", strings.Repeat("a", 152) + "{" + strings.Repeat("b", 10), GSM7, 164,
+			[]string{strings.Repeat("a", 152), "{" + strings.Repeat("b", 10)}},
+		{"70 caracteres UCS-2", strings.Repeat("á", 70), UCS2, 70,
+			[]string{strings.Repeat("á", 70)}},
+		{"71 caracteres UCS-2", strings.Repeat("á", 71), UCS2, 71,
+			[]string{strings.Repeat("á", 67), strings.Repeat("á", 4)}},
+		{"66 acentos y dos emojis", strings.Repeat("á", 66) + emojis, UCS2, 70,
+			[]string{strings.Repeat("á", 66) + emojis}},
+		// El emoji no cabe en la unidad 67: el par sustituto no se corta
+		{"par sustituto en el límite", strings.Repeat("á", 66) + emojis + "x", UCS2, 71,
+			[]string{strings.Repeat("á", 66), emojis + "x"}},
+	}
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			plan := SegmentarSMS(caso.texto, 7)
+			if plan.Codificacion != caso.codificacion || plan.Unidades != caso.unidades {
+				t.Errorf("%s con %d unidades, se esperaba %s con %d",
+					plan.Codificacion, plan.Unidades, caso.codificacion, caso.unidades)
+			}
+			if len(plan.Segmentos) != len(caso.partes) {
+				t.Fatalf("%d segmentos, se esperaban %d", len(plan.Segmentos), len(caso.partes))
+			}
+			unido := ""
+			for i, segmento := range plan.Segmentos {
+				if segmento.Numero != i+1 || segmento.Texto != caso.partes[i] || !utf8.ValidString(segmento.Texto) {
+					t.Errorf("segmento %d: %d %q, se esperaba %q", i, segmento.Numero, segmento.Texto, caso.partes[i])
+				}
+				var udh []byte
+				if len(caso.partes) > 1 {
+					udh = []byte{5, 0, 3, 7, byte(len(caso.partes)), byte(i + 1)}
+				}
+				if !bytes.Equal(segmento.UDH, udh) {
+					t.Errorf("segmento %d: UDH %v, se esperaba %v", i, segmento.UDH, udh)
+				}
+				unido += segmento.Texto
+			}
+			if unido != caso.texto {
+				t.Error("los segmentos no reconstruyen el texto")
+			}
+		})
+	}
+}
+
+func TestCostoSMS(t *testing.T) {
+	sms := NuevoSMSNotificador("clave", "proveedor")
+	sms.ConfigurarTarifa(0.04)
+	casos := []struct {
+		texto     string
+		segmentos int
+		costo     float64
+	}{
+		{"hola", 1, 0.04},
+		{strings.Repeat("a", 161), 2, 0.08},
+		{strings.Repeat("á", 140), 3, 0.12},
+	}
+	for _, caso := range casos {
+		plan, costo := sms.EstimarCosto(caso.texto)
+		if len(plan.Segmentos) != caso.segmentos || math.Abs(costo-caso.costo) > 1e-9 {
+			t.Errorf("EstimarCosto: %d segmentos por %v, se esperaban %d por %v",
+				len(plan.Segmentos), costo, caso.segmentos, caso.costo)
+		}
+		if got := plan.Costo(0.04); got != costo {
+			t.Errorf("PlanSMS.Costo = %v, EstimarCosto = %v", got, costo)
+		}
+	}
+}
+
+func TestSMSEnviarMetadatos(t *testing.T) {
+	sms := NuevoSMSNotificador("clave", "proveedor")
+	sms.reintentos = motorSinEsperas()
+	sms.ConfigurarTarifa(0.04)
+	metadatos := map[string]string{"libro": "Rayuela"}
+
+	// Los reintentos absorben los fallos simulados del proveedor; los
+	// metadatos quedan en el registro de todos modos
+	id, err := sms.Enviar(context.Background(), "987 654 321",
+		Mensaje{Cuerpo: strings.Repeat("a", 161), Metadatos: metadatos})
+	if id == "" {
+		t.Fatalf("Enviar no retornó ID: %v", err)
+	}
+	registro, err := sms.Almacen().Obtener(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	esperados := map[string]string{
+		"libro":            "Rayuela",
+		"sms_codificacion": "GSM-7",
+		"sms_segmentos":    "2",
+		"sms_costo":        "0.08",
+	}
+	for clave, valor := range esperados {
+		if registro.Metadatos[clave] != valor {
+			t.Errorf("metadato %s = %q, se esperaba %q", clave, registro.Metadatos[clave], valor)
+		}
+	}
+	if registro.Destinatario != "+51987654321" {
+		t.Errorf("destinatario %q, se esperaba el formato E.164", registro.Destinatario)
+	}
+	if len(metadatos) != 1 {
+		t.Errorf("Enviar modificó los metadatos del llamador: %v", metadatos)
+	}
+}
+
+func TestSMSMaxSegmentos(t *testing.T) {
+	tresSegmentos := strings.Repeat("a", 153*2+1)
+	casos := []struct {
+		maximo int
+		texto  string
+		error  string
+	}{
+		{maxSegmentosPorDefecto, tresSegmentos, ""},
+		{3, tresSegmentos, ""},
+		{2, tresSegmentos, "3 segmentos (máximo 2)"},
+		{0, strings.Repeat("a", 161), "2 segmentos (máximo 1)"}, // se lleva al mínimo de 1
+		{1, strings.Repeat("a", 160), ""},
+	}
+	for _, caso := range casos {
+		sms := NuevoSMSNotificador("clave", "proveedor")
+		sms.reintentos = motorSinEsperas()
+		if caso.maximo != maxSegmentosPorDefecto {
+			sms.ConfigurarMaxSegmentos(caso.maximo)
+		}
+		if err := sms.ValidarMensaje(caso.texto); caso.error == "" && err != nil ||
+			caso.error != "" && (err == nil || !strings.Contains(err.Error(), caso.error)) {
+			t.Errorf("máximo %d: error %v, se esperaba %q", caso.maximo, err, caso.error)
+		}
+		if caso.error == "" {
+			continue
+		}
+		// Un mensaje que excede el límite no llega a registrarse
+		if id, err := sms.Enviar(context.Background(), "987654321", MensajeDeTexto(caso.texto)); id != "" || err == nil {
+			t.Errorf("máximo %d: Enviar aceptó el mensaje (%q, %v)", caso.maximo, id, err)
+		}
+		if registros, _ := sms.Almacen().Buscar(FiltroRegistros{}); len(registros) != 0 {
+			t.Errorf("máximo %d: quedaron %d registros", caso.maximo, len(registros))
+		}
+	}
+}
+
+func TestSMSValidarDestinatario(t *testing.T) {
+	sms := NuevoSMSNotificador("clave", "proveedor")
+	casos := []struct {
+		numero string
+		valido bool
+	}{
+		{"987 654 321", true},
+		{"01 4567890", false}, // fijo de Lima
+		{"+34 912 345 678", false},
+		{"+52 1 55 1234 5678", true},
+		// Sin plan de numeración no se sabe si es fijo: se acepta
+		{"+44 20 7946 0958", true},
+		{"+54 11 1234 5678", true},
+		{"+598 99 123 456", true},
+		{"+44 1234 5678 9012 34", false},
+	}
+	for _, caso := range casos {
+		if err := sms.ValidarDestinantario(caso.numero); (err == nil) != caso.valido {
+			t.Errorf("ValidarDestinantario(%q): error %v, se esperaba válido = %v", caso.numero, err, caso.valido)
+		}
+	}
+}
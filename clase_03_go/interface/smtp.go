@@ -0,0 +1,271 @@
+package interfaces
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ==========================================
+// ENVÍO REAL DE EMAIL POR SMTP
+// ==========================================
+
+// ModoTLS indica cómo se cifra la conexión con el servidor SMTP
+type ModoTLS int
+
+const (
+	TLSStartTLS  ModoTLS = iota // conexión en claro que se cifra con STARTTLS (puerto 587)
+	TLSImplicito                // TLS desde el primer byte (puerto 465)
+	TLSNinguno                  // sin cifrado; solo para servidores locales de prueba
+)
+
+// MecanismoAuth es el mecanismo SASL usado para autenticarse
+type MecanismoAuth string
+
+const (
+	AuthNinguna MecanismoAuth = ""
+	AuthPlain   MecanismoAuth = "PLAIN"
+	AuthLogin   MecanismoAuth = "LOGIN"
+)
+
+// ConfigurarRemitente define el nombre y la dirección del campo From
+func (e *EmailNotificador) ConfigurarRemitente(nombre, email string) {
+	e.remitente = mail.Address{Name: nombre, Address: email}
+}
+
+// ConfigurarTLS cambia el modo de cifrado; config puede ser nil para
+// usar la verificación normal de certificados
+func (e *EmailNotificador) ConfigurarTLS(modo ModoTLS, config *tls.Config) {
+	e.modoTLS = modo
+	e.tlsConfig = config
+}
+
+// ConfigurarAutenticacion elige PLAIN, LOGIN o ninguna
+func (e *EmailNotificador) ConfigurarAutenticacion(mecanismo MecanismoAuth) {
+	e.autenticar = mecanismo
+}
+
+// modoTLSPorPuerto elige el modo habitual de cada puerto
+func modoTLSPorPuerto(puerto int) ModoTLS {
+	if puerto == 465 {
+		return TLSImplicito
+	}
+	return TLSStartTLS
+}
+
+// ConstruirMensaje arma el mensaje RFC 5322 completo, con líneas CRLF.
+// Si el mensaje tiene varias líneas, la primera se usa como asunto.
+func (e *EmailNotificador) ConstruirMensaje(registro *RegistroNotificacion) []byte {
+	asunto, cuerpo, multilinea := strings.Cut(registro.Mensaje, "\n")
+	if !multilinea {
+		asunto, cuerpo = e.asuntoPorDefecto, registro.Mensaje
+	}
+	asunto = strings.TrimSpace(asunto)
+	cuerpo = strings.TrimLeft(cuerpo, "\r\n")
+
+	_, dominio, _ := strings.Cut(e.remitente.Address, "@")
+	if dominio == "" {
+		dominio = e.servidor
+	}
+
+	var msg bytes.Buffer
+	encabezado := func(nombre, valor string) {
+		fmt.Fprintf(&msg, "%s: %s\r\n", nombre, valor)
+	}
+	encabezado("From", e.remitente.String())
+	encabezado("To", (&mail.Address{Address: registro.Destinatario}).String())
+	encabezado("Subject", mime.QEncoding.Encode("utf-8", asunto))
+	encabezado("Date", e.reloj().Format(time.RFC1123Z))
+	encabezado("Message-ID", fmt.Sprintf("<%s@%s>", registro.ID, dominio))
+	switch registro.Prioridad {
+	case PrioridadAlta, PrioridadUrgente:
+		encabezado("Importance", "high")
+		encabezado("X-Priority", "1")
+	case PrioridadBaja:
+		encabezado("Importance", "low")
+		encabezado("X-Priority", "5")
+	}
+	encabezado("MIME-Version", "1.0")
+
+	if registro.CuerpoHTML == "" {
+		encabezado("Content-Type", "text/plain; charset=utf-8")
+		encabezado("Content-Transfer-Encoding", "quoted-printable")
+		msg.WriteString("\r\n")
+		escribirQP(&msg, cuerpo)
+		return msg.Bytes()
+	}
+
+	// Con HTML se envían las dos versiones; el cliente muestra la última que entienda
+	partes := multipart.NewWriter(&msg)
+	encabezado("Content-Type", fmt.Sprintf("multipart/alternative; boundary=%q", partes.Boundary()))
+	msg.WriteString("\r\n")
+	for _, parte := range []struct{ tipo, contenido string }{
+		{"text/plain; charset=utf-8", cuerpo},
+		{"text/html; charset=utf-8", registro.CuerpoHTML},
+	} {
+		w, _ := partes.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {parte.tipo},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		var b bytes.Buffer
+		escribirQP(&b, parte.contenido)
+		w.Write(b.Bytes())
+	}
+	partes.Close()
+	return msg.Bytes()
+}
+
+// escribirQP codifica el texto en quoted-printable terminando en CRLF
+func escribirQP(msg *bytes.Buffer, texto string) {
+	qp := quotedprintable.NewWriter(msg)
+	qp.Write([]byte(texto))
+	qp.Close()
+	if !bytes.HasSuffix(msg.Bytes(), []byte("\r\n")) {
+		msg.WriteString("\r\n")
+	}
+}
+
+// entregarSMTP abre una conexión, se autentica y entrega el mensaje.
+// El plazo de ctx se aplica a toda la sesión. Las respuestas 5xx del
+// servidor son permanentes; las 4xx y las fallas de red se reintentan.
+func (e *EmailNotificador) entregarSMTP(ctx context.Context, registro *RegistroNotificacion) (err error) {
+	defer func() { err = clasificarErrorSMTP(err) }()
+
+	direccion := net.JoinHostPort(e.servidor, strconv.Itoa(e.puerto))
+	var conn net.Conn
+	if e.modoTLS == TLSImplicito {
+		dialer := &tls.Dialer{Config: e.configTLS()}
+		conn, err = dialer.DialContext(ctx, "tcp", direccion)
+	} else {
+		var dialer net.Dialer
+		conn, err = dialer.DialContext(ctx, "tcp", direccion)
+	}
+	if err != nil {
+		return fmt.Errorf("Servidor SMTP no disponible: %w", err)
+	}
+	if limite, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(limite)
+	}
+	// Cancelar ctx corta la conexión aunque el servidor no responda
+	detener := context.AfterFunc(ctx, func() { conn.Close() })
+	defer detener()
+
+	cliente, err := smtp.NewClient(conn, e.servidor)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("Saludo SMTP fallido: %w", err)
+	}
+	defer cliente.Close()
+
+	if e.modoTLS == TLSStartTLS {
+		if ok, _ := cliente.Extension("STARTTLS"); !ok {
+			return Permanente(errors.New("el servidor SMTP no ofrece STARTTLS"))
+		}
+		if err := cliente.StartTLS(e.configTLS()); err != nil {
+			return fmt.Errorf("STARTTLS fallido: %w", err)
+		}
+	}
+
+	if e.autenticar != AuthNinguna && e.usuario != "" {
+		var auth smtp.Auth
+		switch e.autenticar {
+		case AuthPlain:
+			auth = smtp.PlainAuth("", e.usuario, e.password, e.servidor)
+		case AuthLogin:
+			auth = &loginAuth{usuario: e.usuario, password: e.password, host: e.servidor}
+		default:
+			return Permanente(fmt.Errorf("mecanismo de autenticación no soportado: %s", e.autenticar))
+		}
+		if err := cliente.Auth(auth); err != nil {
+			var respuesta *textproto.Error
+			if !errors.As(err, &respuesta) {
+				// Error local (sin TLS, servidor equivocado): reintentar no sirve
+				return Permanente(fmt.Errorf("autenticación SMTP fallida: %w", err))
+			}
+			return fmt.Errorf("autenticación SMTP fallida: %w", err)
+		}
+	}
+
+	if err := cliente.Mail(e.remitente.Address); err != nil {
+		return fmt.Errorf("remitente rechazado: %w", err)
+	}
+	if err := cliente.Rcpt(registro.Destinatario); err != nil {
+		return fmt.Errorf("destinatario rechazado: %w", err)
+	}
+	w, err := cliente.Data()
+	if err != nil {
+		return fmt.Errorf("DATA rechazado: %w", err)
+	}
+	if _, err := w.Write(e.ConstruirMensaje(registro)); err != nil {
+		return fmt.Errorf("error al escribir el mensaje: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("mensaje rechazado: %w", err)
+	}
+	return cliente.Quit()
+}
+
+// clasificarErrorSMTP marca como permanentes los rechazos 5xx
+func clasificarErrorSMTP(err error) error {
+	var respuesta *textproto.Error
+	if err != nil && !EsPermanente(err) && errors.As(err, &respuesta) && respuesta.Code >= 500 {
+		return Permanente(err)
+	}
+	return err
+}
+
+func (e *EmailNotificador) configTLS() *tls.Config {
+	if e.tlsConfig == nil {
+		return &tls.Config{ServerName: e.servidor, MinVersion: tls.VersionTLS12}
+	}
+	config := e.tlsConfig.Clone()
+	if config.ServerName == "" {
+		config.ServerName = e.servidor
+	}
+	return config
+}
+
+// loginAuth implementa AUTH LOGIN, que net/smtp no incluye. Igual que
+// PlainAuth, se niega a enviar la contraseña sin TLS salvo en localhost.
+type loginAuth struct {
+	usuario, password, host string
+}
+
+func (a *loginAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	if !server.TLS && !esLocal(server.Name) {
+		return "", nil, errors.New("conexión sin cifrar")
+	}
+	if server.Name != a.host {
+		return "", nil, errors.New("nombre de servidor incorrecto")
+	}
+	return "LOGIN", nil, nil
+}
+
+func (a *loginAuth) Next(desafio []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+	switch strings.ToLower(strings.TrimSpace(string(desafio))) {
+	case "username:", "user name", "username":
+		return []byte(a.usuario), nil
+	case "password:", "password":
+		return []byte(a.password), nil
+	}
+	return nil, fmt.Errorf("desafío LOGIN inesperado: %q", desafio)
+}
+
+func esLocal(host string) bool {
+	return host == "localhost" || host == "127.0.0.1" || host == "::1"
+}
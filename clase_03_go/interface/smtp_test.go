@@ -0,0 +1,169 @@
+package interfaces
+
+import (
+	"context"
+	"crypto/tls"
+	"testing"
+	"time"
+)
+
+// relojPrueba fija el encabezado Date para poder comparar bytes exactos
+func relojPrueba() time.Time {
+	return time.Date(2026, time.March, 2, 9, 30, 0, 0, time.UTC)
+}
+
+// levantarSMTP arranca el servidor falso y lo cierra al terminar la prueba
+func levantarSMTP(t *testing.T, seguro, implicito bool) (*servidorSMTPFalso, *tls.Config) {
+	t.Helper()
+	var servidorTLS, clienteTLS *tls.Config
+	if seguro {
+		certificado, pool, err := certificadoAutofirmado("127.0.0.1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		servidorTLS = &tls.Config{Certificates: []tls.Certificate{certificado}}
+		clienteTLS = &tls.Config{RootCAs: pool}
+	}
+	servidor, err := nuevoServidorSMTPFalso(servidorTLS, implicito)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { servidor.Cerrar() })
+	return servidor, clienteTLS
+}
+
+func nuevoEmailPrueba(servidor *servidorSMTPFalso, usuario, password string) *EmailNotificador {
+	email := NuevoEmailNotificador(servidor.Host(), servidor.Puerto(), usuario, password, ConfiguracionNotificacion{})
+	email.ConfigurarRemitente("Biblioteca", "avisos@biblio.test")
+	email.reloj = relojPrueba
+	email.reintentos = motorSinEsperas()
+	return email
+}
+
+// El cuerpo lleva acentos (QP), un "=" literal y líneas que empiezan con
+// punto: si el cliente no los duplicara, el servidor los perdería o
+// cortaría el mensaje en la línea "."
+const cuerpoPrueba = "Hola Ana,\nDevuelve «El Quijote» mañana; multa = 0.\n.firma\n.\nfin"
+
+func datosEsperados(id, encabezadosExtra string) string {
+	return "From: \"Biblioteca\" <avisos@biblio.test>\r\n" +
+		"To: <ana@correo.test>\r\n" +
+		"Subject: =?utf-8?q?Pr=C3=A9stamo_por_vencer?=\r\n" +
+		"Date: Mon, 02 Mar 2026 09:30:00 +0000\r\n" +
+		"Message-ID: <" + id + "@biblio.test>\r\n" +
+		encabezadosExtra +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=utf-8\r\n" +
+		"Content-Transfer-Encoding: quoted-printable\r\n" +
+		"\r\n" +
+		"Hola Ana,\r\n" +
+		"Devuelve =C2=ABEl Quijote=C2=BB ma=C3=B1ana; multa =3D 0.\r\n" +
+		".firma\r\n" +
+		".\r\n" +
+		"fin\r\n"
+}
+
+func TestEmailEntregaSMTP(t *testing.T) {
+	casos := []struct {
+		nombre    string
+		modo      ModoTLS
+		implicito bool
+		auth      MecanismoAuth
+		prioridad Prioridad
+		extra     string
+	}{
+		{"STARTTLS con PLAIN", TLSStartTLS, false, AuthPlain, PrioridadNormal, ""},
+		{"STARTTLS con LOGIN", TLSStartTLS, false, AuthLogin, PrioridadAlta,
+			"Importance: high\r\nX-Priority: 1\r\n"},
+		{"TLS implícito con PLAIN", TLSImplicito, true, AuthPlain, PrioridadBaja,
+			"Importance: low\r\nX-Priority: 5\r\n"},
+		{"TLS implícito con LOGIN", TLSImplicito, true, AuthLogin, PrioridadUrgente,
+			"Importance: high\r\nX-Priority: 1\r\n"},
+	}
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			servidor, clienteTLS := levantarSMTP(t, true, caso.implicito)
+			servidor.Usuarios = map[string]string{"biblio": "clave"}
+			email := nuevoEmailPrueba(servidor, "biblio", "clave")
+			email.ConfigurarTLS(caso.modo, clienteTLS)
+			email.ConfigurarAutenticacion(caso.auth)
+
+			id, err := email.Enviar(context.Background(), "ana@correo.test",
+				Mensaje{Asunto: "Préstamo por vencer", Cuerpo: cuerpoPrueba, Prioridad: caso.prioridad})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			correos := servidor.Correos()
+			if len(correos) != 1 {
+				t.Fatalf("se esperaba un correo y llegaron %d", len(correos))
+			}
+			correo := correos[0]
+			if correo.De != "avisos@biblio.test" || len(correo.Para) != 1 || correo.Para[0] != "ana@correo.test" {
+				t.Errorf("sobre inesperado: MAIL FROM %q, RCPT TO %q", correo.De, correo.Para)
+			}
+			if correo.Usuario != "biblio" {
+				t.Errorf("usuario autenticado %q", correo.Usuario)
+			}
+			if !correo.TLS {
+				t.Error("DATA llegó sin cifrar")
+			}
+			if esperado := datosEsperados(id, caso.extra); string(correo.Datos) != esperado {
+				t.Errorf("datos recibidos:\n%q\nse esperaba:\n%q", correo.Datos, esperado)
+			}
+		})
+	}
+}
+
+func TestEmailRespuestasSMTP(t *testing.T) {
+	casos := []struct {
+		nombre     string
+		password   string
+		rechazo    string
+		intentos   int
+		permanente bool
+	}{
+		{"5xx en RCPT es permanente", "clave", "550 5.1.1 Buzón inexistente", 1, true},
+		{"5xx en AUTH es permanente", "otra", "", 1, true},
+		{"4xx en RCPT se reintenta", "clave", "450 4.2.1 Buzón ocupado", 3, false},
+	}
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			servidor, clienteTLS := levantarSMTP(t, true, false)
+			servidor.Usuarios = map[string]string{"biblio": "clave"}
+			servidor.RechazoRCPT = caso.rechazo
+			email := nuevoEmailPrueba(servidor, "biblio", caso.password)
+			email.ConfigurarTLS(TLSStartTLS, clienteTLS)
+
+			id, err := email.Enviar(context.Background(), "ana@correo.test", Mensaje{Cuerpo: "hola"})
+			if err == nil {
+				t.Fatal("se esperaba un error")
+			}
+			if EsPermanente(err) != caso.permanente {
+				t.Errorf("EsPermanente = %v, se esperaba %v (%v)", EsPermanente(err), caso.permanente, err)
+			}
+			registro, errObtener := email.Almacen().Obtener(id)
+			if errObtener != nil {
+				t.Fatal(errObtener)
+			}
+			if registro.Intentos != caso.intentos || registro.Estado != Fallida {
+				t.Errorf("registro con %d intentos y estado %s, se esperaban %d y %s",
+					registro.Intentos, registro.Estado, caso.intentos, Fallida)
+			}
+			if got := len(servidor.Correos()); got != 0 {
+				t.Errorf("el servidor aceptó %d correos", got)
+			}
+		})
+	}
+}
+
+func TestEmailSinSTARTTLSEsPermanente(t *testing.T) {
+	servidor, _ := levantarSMTP(t, false, false)
+	email := nuevoEmailPrueba(servidor, "biblio", "clave")
+	email.ConfigurarTLS(TLSStartTLS, nil)
+
+	_, err := email.Enviar(context.Background(), "ana@correo.test", Mensaje{Cuerpo: "hola"})
+	if !EsPermanente(err) {
+		t.Errorf("sin STARTTLS no debe enviarse en claro ni reintentarse: %v", err)
+	}
+}
@@ -0,0 +1,315 @@
+package interfaces
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ==========================================
+// SERVIDOR SMTP FALSO PARA PRUEBAS
+// ==========================================
+
+// correoRecibido es un mensaje aceptado por el servidor falso
+type correoRecibido struct {
+	De      string
+	Para    []string
+	Datos   []byte // mensaje exacto, con CRLF y sin el relleno de puntos
+	Usuario string // usuario autenticado; vacío si no hubo AUTH
+	TLS     bool   // true si la sesión estaba cifrada al recibir DATA
+}
+
+// servidorSMTPFalso entiende lo suficiente de SMTP para probar
+// EmailNotificador: EHLO, STARTTLS, AUTH PLAIN/LOGIN, MAIL, RCPT y DATA.
+// Escucha en 127.0.0.1 en un puerto libre.
+type servidorSMTPFalso struct {
+	// Usuarios válidos y sus contraseñas; nil acepta envíos sin AUTH
+	Usuarios map[string]string
+	// RechazoRCPT, si no está vacío, es la respuesta a todo RCPT TO
+	// (por ejemplo "550 5.1.1 Buzón inexistente")
+	RechazoRCPT string
+
+	listener  net.Listener
+	tlsConfig *tls.Config
+	implicito bool
+
+	mu      sync.Mutex
+	correos []correoRecibido
+	wg      sync.WaitGroup
+}
+
+// nuevoServidorSMTPFalso empieza a escuchar. Con tlsConfig nil no ofrece
+// STARTTLS; con implicito, toda conexión es TLS desde el inicio.
+func nuevoServidorSMTPFalso(tlsConfig *tls.Config, implicito bool) (*servidorSMTPFalso, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	if implicito {
+		if tlsConfig == nil {
+			listener.Close()
+			return nil, fmt.Errorf("TLS implícito necesita una configuración TLS")
+		}
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+	s := &servidorSMTPFalso{listener: listener, tlsConfig: tlsConfig, implicito: implicito}
+	s.wg.Add(1)
+	go s.aceptar()
+	return s, nil
+}
+
+// Host retorna la dirección IP en la que escucha
+func (s *servidorSMTPFalso) Host() string {
+	return s.listener.Addr().(*net.TCPAddr).IP.String()
+}
+
+// Puerto retorna el puerto asignado
+func (s *servidorSMTPFalso) Puerto() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+// Correos retorna una copia de los mensajes recibidos
+func (s *servidorSMTPFalso) Correos() []correoRecibido {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]correoRecibido(nil), s.correos...)
+}
+
+// Cerrar deja de escuchar y espera a que terminen las sesiones abiertas
+func (s *servidorSMTPFalso) Cerrar() error {
+	err := s.listener.Close()
+	s.wg.Wait()
+	return err
+}
+
+func (s *servidorSMTPFalso) aceptar() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer conn.Close()
+			conn.SetDeadline(time.Now().Add(30 * time.Second))
+			s.atender(conn)
+		}()
+	}
+}
+
+// sesionSMTP es el estado de una conexión
+type sesionSMTP struct {
+	conn    net.Conn
+	tp      *textproto.Conn
+	tls     bool
+	usuario string
+	de      string
+	para    []string
+}
+
+func (s *servidorSMTPFalso) atender(conn net.Conn) {
+	sesion := &sesionSMTP{conn: conn, tp: textproto.NewConn(conn), tls: s.implicito}
+	sesion.responder("220 smtp.falso ESMTP listo")
+	for {
+		linea, err := sesion.tp.ReadLine()
+		if err != nil {
+			return
+		}
+		comando, argumento, _ := strings.Cut(linea, " ")
+		switch strings.ToUpper(comando) {
+		case "EHLO", "HELO":
+			extensiones := []string{"smtp.falso", "8BITMIME", "AUTH PLAIN LOGIN"}
+			if s.tlsConfig != nil && !sesion.tls {
+				extensiones = append(extensiones, "STARTTLS")
+			}
+			for i, extension := range extensiones {
+				separador := "-"
+				if i == len(extensiones)-1 {
+					separador = " "
+				}
+				sesion.responder("250" + separador + extension)
+			}
+		case "STARTTLS":
+			if s.tlsConfig == nil || sesion.tls {
+				sesion.responder("502 5.5.1 STARTTLS no disponible")
+				continue
+			}
+			sesion.responder("220 2.0.0 Listo para TLS")
+			cifrada := tls.Server(conn, s.tlsConfig)
+			if err := cifrada.Handshake(); err != nil {
+				return
+			}
+			// Tras STARTTLS se descarta todo el estado anterior (RFC 3207)
+			sesion = &sesionSMTP{conn: cifrada, tp: textproto.NewConn(cifrada), tls: true}
+		case "AUTH":
+			s.autenticar(sesion, argumento)
+		case "MAIL":
+			if s.Usuarios != nil && sesion.usuario == "" {
+				sesion.responder("530 5.7.0 Autenticación requerida")
+				continue
+			}
+			sesion.de = extraerDireccion(argumento)
+			sesion.para = nil
+			sesion.responder("250 2.1.0 OK")
+		case "RCPT":
+			if sesion.de == "" {
+				sesion.responder("503 5.5.1 Falta MAIL FROM")
+				continue
+			}
+			if s.RechazoRCPT != "" {
+				sesion.responder(s.RechazoRCPT)
+				continue
+			}
+			sesion.para = append(sesion.para, extraerDireccion(argumento))
+			sesion.responder("250 2.1.5 OK")
+		case "DATA":
+			if len(sesion.para) == 0 {
+				sesion.responder("503 5.5.1 Falta RCPT TO")
+				continue
+			}
+			sesion.responder("354 Termine con <CRLF>.<CRLF>")
+			datos, err := leerDatos(sesion.tp.Reader.R)
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.correos = append(s.correos, correoRecibido{
+				De: sesion.de, Para: sesion.para, Datos: datos, Usuario: sesion.usuario, TLS: sesion.tls,
+			})
+			s.mu.Unlock()
+			sesion.de, sesion.para = "", nil
+			sesion.responder("250 2.0.0 Mensaje aceptado")
+		case "RSET":
+			sesion.de, sesion.para = "", nil
+			sesion.responder("250 2.0.0 OK")
+		case "NOOP":
+			sesion.responder("250 2.0.0 OK")
+		case "QUIT":
+			sesion.responder("221 2.0.0 Adiós")
+			return
+		default:
+			sesion.responder("502 5.5.2 Comando no reconocido")
+		}
+	}
+}
+
+func (s *servidorSMTPFalso) autenticar(sesion *sesionSMTP, argumento string) {
+	mecanismo, inicial, _ := strings.Cut(argumento, " ")
+	var usuario, password string
+	switch strings.ToUpper(mecanismo) {
+	case "PLAIN":
+		if inicial == "" {
+			inicial = sesion.desafio("")
+		}
+		credenciales, err := base64.StdEncoding.DecodeString(inicial)
+		partes := strings.Split(string(credenciales), "\x00")
+		if err != nil || len(partes) != 3 {
+			sesion.responder("501 5.5.2 Credenciales mal formadas")
+			return
+		}
+		usuario, password = partes[1], partes[2]
+	case "LOGIN":
+		u, err1 := base64.StdEncoding.DecodeString(sesion.desafio("Username:"))
+		p, err2 := base64.StdEncoding.DecodeString(sesion.desafio("Password:"))
+		if err1 != nil || err2 != nil {
+			sesion.responder("501 5.5.2 Credenciales mal formadas")
+			return
+		}
+		usuario, password = string(u), string(p)
+	default:
+		sesion.responder("504 5.5.4 Mecanismo no soportado")
+		return
+	}
+	if esperada, ok := s.Usuarios[usuario]; s.Usuarios != nil && (!ok || esperada != password) {
+		sesion.responder("535 5.7.8 Credenciales inválidas")
+		return
+	}
+	sesion.usuario = usuario
+	sesion.responder("235 2.7.0 Autenticado")
+}
+
+func (s *sesionSMTP) responder(linea string) {
+	s.tp.PrintfLine("%s", linea)
+}
+
+// desafio envía un 334 con el texto en base64 y retorna la respuesta
+func (s *sesionSMTP) desafio(texto string) string {
+	s.responder("334 " + base64.StdEncoding.EncodeToString([]byte(texto)))
+	respuesta, _ := s.tp.ReadLine()
+	return strings.TrimSpace(respuesta)
+}
+
+// leerDatos lee hasta la línea "." sin normalizar los finales de línea,
+// para que las pruebas puedan comparar los bytes exactos
+func leerDatos(r *bufio.Reader) ([]byte, error) {
+	var datos []byte
+	for {
+		linea, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		if linea == ".\r\n" {
+			return datos, nil
+		}
+		datos = append(datos, strings.TrimPrefix(linea, ".")...)
+	}
+}
+
+// extraerDireccion toma lo que está entre < > en "FROM:<a@b.c> SIZE=10"
+func extraerDireccion(argumento string) string {
+	inicio := strings.Index(argumento, "<")
+	fin := strings.Index(argumento, ">")
+	if inicio < 0 || fin < inicio {
+		return ""
+	}
+	return argumento[inicio+1 : fin]
+}
+
+// certificadoAutofirmado genera un certificado para host y un pool que
+// confía en él, para probar STARTTLS y TLS implícito sin archivos
+func certificadoAutofirmado(host string) (tls.Certificate, *x509.CertPool, error) {
+	clave, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	plantilla := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: host},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		plantilla.IPAddresses = []net.IP{ip}
+	} else {
+		plantilla.DNSNames = []string{host}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, plantilla, plantilla, &clave.PublicKey, clave)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	certificado, err := x509.ParseCertificate(der)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(certificado)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: clave, Leaf: certificado}, pool, nil
+}
@@ -0,0 +1,179 @@
+package interfaces
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ==========================================
+// API V2: CONTEXTO, MENSAJE ESTRUCTURADO E ID
+// ==========================================
+
+// Prioridad indica la urgencia del mensaje; cada canal la traduce a su
+// mecanismo (encabezado Importance en email, Urgency en push...)
+type Prioridad int
+
+const (
+	PrioridadNormal Prioridad = iota
+	PrioridadBaja
+	PrioridadAlta
+	PrioridadUrgente
+)
+
+func (p Prioridad) String() string {
+	switch p {
+	case PrioridadBaja:
+		return "baja"
+	case PrioridadAlta:
+		return "alta"
+	case PrioridadUrgente:
+		return "urgente"
+	}
+	return "normal"
+}
+
+// Mensaje es el contenido de una notificación
+type Mensaje struct {
+	Asunto     string // vacío en canales sin asunto
+	Cuerpo     string
+	CuerpoHTML string            // alternativa HTML de Cuerpo; solo la usa email
+	Metadatos  map[string]string // datos del llamador, se guardan en el registro
+	Prioridad  Prioridad
+}
+
+// MensajeDeTexto convierte el texto de la API anterior: si tiene varias
+// líneas, la primera es el asunto
+func MensajeDeTexto(texto string) Mensaje {
+	asunto, cuerpo, multilinea := strings.Cut(texto, "\n")
+	if !multilinea {
+		return Mensaje{Cuerpo: texto}
+	}
+	return Mensaje{Asunto: strings.TrimSpace(asunto), Cuerpo: cuerpo}
+}
+
+// Texto es la forma de una sola cadena que entienden los canales:
+// asunto en la primera línea y cuerpo a continuación
+func (m Mensaje) Texto() string {
+	if m.Asunto == "" {
+		return m.Cuerpo
+	}
+	return m.Asunto + "\n" + m.Cuerpo
+}
+
+// NotificadorV2 envía con contexto (cancelación y plazo) y retorna el ID
+// con el que luego se consulta Rastreador.ObtenerEstado. Enviar debe
+// poder llamarse desde varias goroutines a la vez: el Despachador lo hace.
+type NotificadorV2 interface {
+	Enviar(ctx context.Context, destinatario string, mensaje Mensaje) (string, error)
+}
+
+// NotificadorV2Avanzado es la versión v2 de NotificadorAvanzado
+type NotificadorV2Avanzado interface {
+	NotificadorV2
+	ValidadorMensaje
+	Rastreador
+	Logger
+}
+
+// Verificación en compilación de que los notificadores cumplen la API v2
+var (
+	_ NotificadorV2Avanzado = (*EmailNotificador)(nil)
+	_ NotificadorV2Avanzado = (*SMSNotificador)(nil)
+	_ NotificadorV2Avanzado = (*SlackNotificador)(nil)
+	_ NotificadorV2Avanzado = (*PushNotificador)(nil)
+)
+
+// ==========================================
+// ADAPTADORES ENTRE VERSIONES
+// ==========================================
+
+// AdaptadorV2 da la API v2 a un Notificador que solo tiene la anterior.
+// Como el notificador envuelto no acepta contexto, si ctx se cancela
+// Enviar retorna enseguida pero el envío sigue en segundo plano; su
+// resultado final queda en el registro del adaptador.
+type AdaptadorV2 struct {
+	notificador Notificador
+
+	mu        sync.Mutex
+	secuencia int
+	rastreo
+}
+
+// NuevoAdaptadorV2 envuelve un notificador de la API anterior
+func NuevoAdaptadorV2(notificador Notificador, tipo TipoNotificacion) *AdaptadorV2 {
+	return &AdaptadorV2{
+		notificador: notificador,
+		rastreo:     nuevoRastreo(tipo),
+	}
+}
+
+// Enviar implementa NotificadorV2
+func (a *AdaptadorV2) Enviar(ctx context.Context, destinatario string, m Mensaje) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	a.mu.Lock()
+	a.secuencia++
+	id := fmt.Sprintf("%s_v2_%d_%d", a.tipo, time.Now().UnixNano(), a.secuencia)
+	a.mu.Unlock()
+	registro := &RegistroNotificacion{
+		ID:           id,
+		Tipo:         a.tipo,
+		Destinatario: destinatario,
+		Mensaje:      m.Texto(),
+		Estado:       Pendiente,
+		Timestamp:    time.Now(),
+		Intentos:     1,
+		Prioridad:    m.Prioridad,
+		Metadatos:    m.Metadatos,
+	}
+	if err := a.registrar(registro); err != nil {
+		return "", err
+	}
+
+	resultado := make(chan error, 1)
+	go func() {
+		err := a.notificador.EnviarNotificacion(destinatario, m.Texto())
+		registro.Error = err
+		if err != nil {
+			a.finalizar(registro, Fallida, err.Error())
+		} else {
+			a.finalizar(registro, Enviado, "")
+		}
+		resultado <- err
+	}()
+
+	select {
+	case err := <-resultado:
+		return id, err
+	case <-ctx.Done():
+		return id, ctx.Err()
+	}
+}
+
+// AdaptadorV1 da la API anterior a un NotificadorV2, para el código que
+// todavía llama a EnviarNotificacion
+type AdaptadorV1 struct {
+	notificador NotificadorV2
+}
+
+// NuevoAdaptadorV1 envuelve un notificador v2
+func NuevoAdaptadorV1(notificador NotificadorV2) *AdaptadorV1 {
+	return &AdaptadorV1{notificador: notificador}
+}
+
+// EnviarNotificacion implementa Notificador sin plazo ni cancelación
+func (a *AdaptadorV1) EnviarNotificacion(destinatario, mensaje string) error {
+	_, err := a.notificador.Enviar(context.Background(), destinatario, MensajeDeTexto(mensaje))
+	return err
+}
+
+var (
+	_ NotificadorV2      = (*AdaptadorV2)(nil)
+	_ Rastreador         = (*AdaptadorV2)(nil)
+	_ ActualizadorEstado = (*AdaptadorV2)(nil)
+	_ Notificador        = (*AdaptadorV1)(nil)
+)
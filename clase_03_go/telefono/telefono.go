@@ -0,0 +1,329 @@
+// Package telefono analiza números telefónicos y los normaliza al formato
+// E.164 (+51987654321) según el plan de numeración de cada país.
+package telefono
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ==========================================
+// TIPOS
+// ==========================================
+
+// Tipo distingue celulares de fijos cuando el plan de numeración lo permite
+type Tipo int
+
+const (
+	// Desconocido: el plan no separa celulares de fijos (México, EE. UU.)
+	// o el número es especial (gratuito, tarifa compartida...)
+	Desconocido Tipo = iota
+	Movil
+	Fijo
+)
+
+func (t Tipo) String() string {
+	switch t {
+	case Movil:
+		return "móvil"
+	case Fijo:
+		return "fijo"
+	}
+	return "desconocido"
+}
+
+// Numero es un teléfono ya validado
+type Numero struct {
+	Pais       string // código ISO 3166: "PE", "CL", "MX", "ES", "US"; vacío si el país no tiene plan
+	CodigoPais string // "51"
+	Nacional   string // número nacional significativo, solo dígitos y sin prefijo troncal
+	Tipo       Tipo
+}
+
+// E164 retorna el número en formato internacional sin separadores
+func (n Numero) E164() string {
+	return "+" + n.CodigoPais + n.Nacional
+}
+
+func (n Numero) String() string {
+	return n.E164()
+}
+
+// ErrNumeroInvalido envuelve todos los errores de Analizar
+var ErrNumeroInvalido = errors.New("número de teléfono inválido")
+
+// ==========================================
+// PLANES DE NUMERACIÓN
+// ==========================================
+
+// plan describe las reglas de un país
+type plan struct {
+	pais   string
+	codigo string
+	// troncales son los prefijos que se marcan dentro del país y no forman
+	// parte del número (el 0 de "01 4567890" en Lima)
+	troncales []string
+	// clasificar valida el número nacional y retorna su tipo
+	clasificar func(nacional string) (Tipo, error)
+}
+
+var planes = []plan{
+	{
+		pais: "PE", codigo: "51", troncales: []string{"0"},
+		// Celulares: 9 dígitos que empiezan con 9. Fijos: Lima 1 + 7
+		// dígitos; provincias código de área de 2 dígitos (41–84) + 6.
+		clasificar: func(n string) (Tipo, error) {
+			switch {
+			case len(n) == 9 && n[0] == '9':
+				return Movil, nil
+			case len(n) == 8 && n[0] == '1':
+				return Fijo, nil
+			case len(n) == 8 && n[:2] >= "41" && n[:2] <= "84":
+				return Fijo, nil
+			}
+			return Desconocido, errors.New("en Perú se esperan 9 dígitos (celular) u 8 (fijo)")
+		},
+	},
+	{
+		pais: "CL", codigo: "56",
+		// Desde 2016 todos los números tienen 9 dígitos: los celulares
+		// empiezan con 9 y los fijos con el código de área (2 en Santiago)
+		clasificar: func(n string) (Tipo, error) {
+			if len(n) != 9 {
+				return Desconocido, errors.New("en Chile se esperan 9 dígitos")
+			}
+			switch {
+			case n[0] == '9':
+				return Movil, nil
+			case n[0] >= '2' && n[0] <= '7':
+				return Fijo, nil
+			}
+			return Desconocido, errors.New("prefijo chileno no asignado")
+		},
+	},
+	{
+		pais: "MX", codigo: "52", troncales: []string{"044", "045", "01"},
+		// Desde 2019 son 10 dígitos para todo tipo de línea; el "1" que
+		// antes se agregaba a los celulares después del +52 se descarta
+		clasificar: func(n string) (Tipo, error) {
+			if len(n) != 10 || n[0] == '0' {
+				return Desconocido, errors.New("en México se esperan 10 dígitos")
+			}
+			return Desconocido, nil
+		},
+	},
+	{
+		pais: "ES", codigo: "34",
+		// 9 dígitos: 6 y 7 celulares, 8 y 9 fijos salvo 80x y 90x, que
+		// son números especiales (gratuitos, tarifa compartida...)
+		clasificar: func(n string) (Tipo, error) {
+			if len(n) != 9 {
+				return Desconocido, errors.New("en España se esperan 9 dígitos")
+			}
+			switch {
+			case n[0] == '6' || n[0] == '7':
+				return Movil, nil
+			case (n[0] == '8' || n[0] == '9') && n[1] != '0':
+				return Fijo, nil
+			case n[0] == '8' || n[0] == '9':
+				return Desconocido, nil
+			}
+			return Desconocido, errors.New("prefijo español no asignado")
+		},
+	},
+	{
+		pais: "US", codigo: "1", troncales: []string{"1"},
+		// Plan norteamericano NXX-NXX-XXXX; no separa celulares de fijos
+		clasificar: func(n string) (Tipo, error) {
+			if len(n) != 10 {
+				return Desconocido, errors.New("en EE. UU. se esperan 10 dígitos")
+			}
+			if n[0] < '2' || n[3] < '2' {
+				return Desconocido, errors.New("el código de área y la central no pueden empezar con 0 ni 1")
+			}
+			if n[1:3] == "11" {
+				return Desconocido, errors.New("los códigos N11 son de servicio")
+			}
+			return Desconocido, nil
+		},
+	},
+}
+
+func planPorPais(pais string) (plan, bool) {
+	for _, p := range planes {
+		if strings.EqualFold(p.pais, pais) {
+			return p, true
+		}
+	}
+	return plan{}, false
+}
+
+// planPorCodigo busca el país por su código internacional
+func planPorCodigo(codigo string) (plan, bool) {
+	for _, p := range planes {
+		if p.codigo == codigo {
+			return p, true
+		}
+	}
+	return plan{}, false
+}
+
+// Límites de E.164 para los países sin plan propio
+const (
+	maxDigitosE164     = 15
+	minDigitosNacional = 4
+)
+
+// codigosDosDigitos son, por cada primer dígito, los segundos dígitos que
+// cierran un código de país de dos cifras; el resto usa tres. Los códigos
+// no son prefijo unos de otros, así que el largo sale de los primeros
+// dígitos. Las zonas 1 (plan norteamericano) y 7 usan una sola cifra.
+var codigosDosDigitos = map[byte]string{
+	'2': "07",
+	'3': "0123469",
+	'4': "013456789",
+	'5': "12345678",
+	'6': "0123456",
+	'8': "1246",
+	'9': "0123458",
+}
+
+// separarCodigoPais divide los dígitos internacionales en código de país
+// y número nacional
+func separarCodigoPais(digitos string) (codigo, nacional string, err error) {
+	if len(digitos) < 2 || digitos[0] == '0' {
+		return "", "", errors.New("código de país no válido")
+	}
+	largo := 3
+	switch {
+	case digitos[0] == '1' || digitos[0] == '7':
+		largo = 1
+	case strings.IndexByte(codigosDosDigitos[digitos[0]], digitos[1]) >= 0:
+		largo = 2
+	}
+	if len(digitos) <= largo {
+		return "", "", errors.New("falta el número después del código de país")
+	}
+	return digitos[:largo], digitos[largo:], nil
+}
+
+// clasificarGenerico aplica solo los límites de E.164: sin plan no se
+// sabe si el número es celular o fijo
+func clasificarGenerico(codigo, nacional string) (Tipo, error) {
+	if len(codigo)+len(nacional) > maxDigitosE164 {
+		return Desconocido, fmt.Errorf("E.164 admite como máximo %d dígitos", maxDigitosE164)
+	}
+	if len(nacional) < minDigitosNacional {
+		return Desconocido, errors.New("número nacional demasiado corto")
+	}
+	return Desconocido, nil
+}
+
+// Soportado indica si hay reglas para el país
+func Soportado(pais string) bool {
+	_, ok := planPorPais(pais)
+	return ok
+}
+
+// ==========================================
+// ANÁLISIS
+// ==========================================
+
+// Analizar valida y normaliza un número. Acepta espacios, guiones, puntos
+// y paréntesis. Si empieza con + o 00 se toma el código de país del
+// número; si no, se interpreta como número nacional de paisPorDefecto
+// (que puede ir vacío para exigir el formato internacional). Los números
+// internacionales de países sin plan solo se validan contra E.164 y
+// quedan con Tipo Desconocido.
+func Analizar(texto, paisPorDefecto string) (Numero, error) {
+	limpio, err := limpiar(texto)
+	if err != nil {
+		return Numero{}, invalido(texto, err)
+	}
+
+	var p plan
+	var nacional string
+	switch {
+	case strings.HasPrefix(limpio, "+") || strings.HasPrefix(limpio, "00"):
+		internacional := strings.TrimPrefix(strings.TrimPrefix(limpio, "+"), "00")
+		codigo, resto, err := separarCodigoPais(internacional)
+		if err != nil {
+			return Numero{}, invalido(texto, err)
+		}
+		var ok bool
+		if p, ok = planPorCodigo(codigo); !ok {
+			tipo, err := clasificarGenerico(codigo, resto)
+			if err != nil {
+				return Numero{}, invalido(texto, err)
+			}
+			return Numero{CodigoPais: codigo, Nacional: resto, Tipo: tipo}, nil
+		}
+		nacional = resto
+		if p.pais == "MX" && len(nacional) == 11 && nacional[0] == '1' {
+			nacional = nacional[1:]
+		}
+	case paisPorDefecto == "":
+		return Numero{}, invalido(texto, errors.New("falta el código de país (+51...)"))
+	default:
+		var ok bool
+		if p, ok = planPorPais(paisPorDefecto); !ok {
+			return Numero{}, invalido(texto, fmt.Errorf("país no soportado: %s", paisPorDefecto))
+		}
+		nacional = quitarTroncal(p, limpio)
+	}
+
+	tipo, err := p.clasificar(nacional)
+	if err != nil {
+		return Numero{}, invalido(texto, err)
+	}
+	return Numero{Pais: p.pais, CodigoPais: p.codigo, Nacional: nacional, Tipo: tipo}, nil
+}
+
+// Normalizar es Analizar cuando solo interesa el formato E.164
+func Normalizar(texto, paisPorDefecto string) (string, error) {
+	numero, err := Analizar(texto, paisPorDefecto)
+	if err != nil {
+		return "", err
+	}
+	return numero.E164(), nil
+}
+
+// limpiar quita los separadores; solo deja dígitos y un + inicial
+func limpiar(texto string) (string, error) {
+	var b strings.Builder
+	for i, r := range strings.TrimSpace(texto) {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '+' && i == 0:
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", fmt.Errorf("carácter no permitido %q", r)
+		}
+	}
+	if strings.TrimPrefix(b.String(), "+") == "" {
+		return "", errors.New("vacío")
+	}
+	return b.String(), nil
+}
+
+// quitarTroncal saca el prefijo troncal solo si lo que queda es válido,
+// porque en algunos países (EE. UU.) el mismo dígito puede iniciar el número
+func quitarTroncal(p plan, numero string) string {
+	for _, troncal := range p.troncales {
+		resto, ok := strings.CutPrefix(numero, troncal)
+		if !ok {
+			continue
+		}
+		if _, err := p.clasificar(resto); err == nil {
+			return resto
+		}
+	}
+	return numero
+}
+
+func invalido(texto string, motivo error) error {
+	return fmt.Errorf("%w '%s': %v", ErrNumeroInvalido, texto, motivo)
+}
@@ -0,0 +1,131 @@
+package telefono
+
+import (
+	"errors"
+	"testing"
+)
+
+type casoAnalizar struct {
+	texto  string
+	e164   string // vacío si el número debe rechazarse
+	codigo string // código de país esperado
+	tipo   Tipo
+}
+
+func probarAnalizar(t *testing.T, paisPorDefecto, paisEsperado string, casos []casoAnalizar) {
+	t.Helper()
+	for _, caso := range casos {
+		numero, err := Analizar(caso.texto, paisPorDefecto)
+		if caso.e164 == "" {
+			if !errors.Is(err, ErrNumeroInvalido) {
+				t.Errorf("Analizar(%q, %q) = %v, %v; se esperaba un número inválido",
+					caso.texto, paisPorDefecto, numero, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Analizar(%q, %q): %v", caso.texto, paisPorDefecto, err)
+			continue
+		}
+		if numero.E164() != caso.e164 || numero.Tipo != caso.tipo || numero.Pais != paisEsperado ||
+			numero.CodigoPais != caso.codigo {
+			t.Errorf("Analizar(%q, %q) = %s %s %q (+%s); se esperaba %s %s %q (+%s)",
+				caso.texto, paisPorDefecto, numero.E164(), numero.Tipo, numero.Pais, numero.CodigoPais,
+				caso.e164, caso.tipo, paisEsperado, caso.codigo)
+		}
+	}
+}
+
+func TestAnalizarPeru(t *testing.T) {
+	probarAnalizar(t, "PE", "PE", []casoAnalizar{
+		{"987 654 321", "+51987654321", "51", Movil},
+		{"+51 987-654-321", "+51987654321", "51", Movil},
+		{"0051 987 654 321", "+51987654321", "51", Movil},
+		{"01 4567890", "+5114567890", "51", Fijo}, // Lima con prefijo troncal
+		{"(1) 456-7890", "+5114567890", "51", Fijo},
+		{"044 123456", "+5144123456", "51", Fijo}, // Trujillo
+		{"98765432", "", "", Desconocido},
+		{"+51 12345", "", "", Desconocido},
+	})
+}
+
+func TestAnalizarChile(t *testing.T) {
+	probarAnalizar(t, "CL", "CL", []casoAnalizar{
+		{"9 1234 5678", "+56912345678", "56", Movil},
+		{"+56 2 2345 6789", "+56223456789", "56", Fijo},
+		{"+56 1 2345 6789", "", "", Desconocido},
+		{"9 1234 567", "", "", Desconocido},
+	})
+}
+
+func TestAnalizarMexico(t *testing.T) {
+	probarAnalizar(t, "MX", "MX", []casoAnalizar{
+		{"55 1234 5678", "+525512345678", "52", Desconocido},
+		{"044 55 1234 5678", "+525512345678", "52", Desconocido}, // celular con el troncal antiguo
+		{"01 55 1234 5678", "+525512345678", "52", Desconocido},
+		{"+52 1 55 1234 5678", "+525512345678", "52", Desconocido}, // el 1 de los celulares se descarta
+		{"+52 55 1234 5678", "+525512345678", "52", Desconocido},
+		{"55 1234 567", "", "", Desconocido},
+	})
+}
+
+func TestAnalizarEspana(t *testing.T) {
+	probarAnalizar(t, "ES", "ES", []casoAnalizar{
+		{"612 345 678", "+34612345678", "34", Movil},
+		{"+34 712 345 678", "+34712345678", "34", Movil},
+		{"0034 912 345 678", "+34912345678", "34", Fijo},
+		{"900 123 456", "+34900123456", "34", Desconocido}, // gratuito
+		{"803 123 456", "+34803123456", "34", Desconocido},
+		{"512 345 678", "", "", Desconocido},
+		{"612 34 56", "", "", Desconocido},
+	})
+}
+
+func TestAnalizarEstadosUnidos(t *testing.T) {
+	probarAnalizar(t, "US", "US", []casoAnalizar{
+		{"(212) 555-0123", "+12125550123", "1", Desconocido},
+		{"1-212-555-0123", "+12125550123", "1", Desconocido}, // prefijo troncal
+		{"+1 212 555 0123", "+12125550123", "1", Desconocido},
+		{"+1 911 555 0123", "", "", Desconocido}, // N11
+		{"+1 212 155 0123", "", "", Desconocido}, // la central no puede empezar con 1
+		{"+1 012 555 0123", "", "", Desconocido}, // el código de área tampoco
+		{"212 555 012", "", "", Desconocido},
+	})
+}
+
+func TestAnalizarSinPlan(t *testing.T) {
+	// Solo se validan los límites de E.164; el tipo queda sin determinar
+	probarAnalizar(t, "PE", "", []casoAnalizar{
+		{"+44 20 7946 0958", "+442079460958", "44", Desconocido},
+		{"+54 11 1234 5678", "+541112345678", "54", Desconocido},
+		{"+598 99 123 456", "+59899123456", "598", Desconocido},
+		{"0049 30 123456", "+4930123456", "49", Desconocido},
+		{"+7 912 345 67 89", "+79123456789", "7", Desconocido},
+		{"+86 138 0013 8000", "+8613800138000", "86", Desconocido},
+		{"+1 1", "", "", Desconocido},
+		{"+44 1234 5678 9012 34", "", "", Desconocido}, // 16 dígitos
+		{"+44 123", "", "", Desconocido},
+		{"+0 123 4567", "", "", Desconocido},
+		{"+598", "", "", Desconocido},
+		{"+44 20 7946 ABCD", "", "", Desconocido},
+	})
+}
+
+func TestAnalizarPaisPorDefecto(t *testing.T) {
+	casos := []struct {
+		texto, pais string
+	}{
+		{"987654321", ""},      // sin país hay que usar el formato internacional
+		{"11 1234 5678", "AR"}, // país sin plan
+		{"", "PE"},
+		{"+", "PE"},
+	}
+	for _, caso := range casos {
+		if _, err := Analizar(caso.texto, caso.pais); !errors.Is(err, ErrNumeroInvalido) {
+			t.Errorf("Analizar(%q, %q): error %v, se esperaba ErrNumeroInvalido", caso.texto, caso.pais, err)
+		}
+	}
+	if Soportado("AR") || !Soportado("pe") {
+		t.Error("Soportado no coincide con los planes definidos")
+	}
+}